@@ -0,0 +1,127 @@
+package configo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+func (b *Backoff) Validate() error {
+	var errs []error
+
+	if b.Attempts < 1 {
+		errs = append(errs, fieldErrorf("attempts", "должно быть не меньше 1"))
+	}
+	if b.Initial < 0 {
+		errs = append(errs, fieldErrorf("initial", "не может быть отрицательным"))
+	}
+	if b.Max < 0 {
+		errs = append(errs, fieldErrorf("max", "не может быть отрицательным"))
+	}
+	if b.Max > 0 && b.Max < b.Initial {
+		errs = append(errs, fieldErrorf("max", "%s меньше initial (%s)", b.Max, b.Initial))
+	}
+	if b.Multiplier < 1 {
+		errs = append(errs, fieldErrorf("multiplier", "должно быть не меньше 1"))
+	}
+	if b.Jitter < 0 || b.Jitter > 1 {
+		errs = append(errs, fieldErrorf("jitter", "должно быть от 0 до 1"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Delay возвращает задержку после попытки с номером attempt (начиная с 1)
+func (b Backoff) Delay(attempt int) time.Duration {
+	delay := float64(b.Initial) * math.Pow(max(b.Multiplier, 1), float64(max(attempt-1, 0)))
+	if b.Max > 0 {
+		delay = min(delay, float64(b.Max))
+	}
+
+	if b.Jitter > 0 {
+		delay += delay * b.Jitter * (2*rand.Float64() - 1)
+	}
+
+	// Без Max степень быстро выходит за пределы time.Duration, а 0 * Inf дает NaN
+	switch {
+	case math.IsNaN(delay):
+		return 0
+	case delay >= math.MaxInt64:
+		return time.Duration(math.MaxInt64)
+	}
+
+	return time.Duration(delay)
+}
+
+// Retry вызывает fn, пока она не завершится без ошибки или не закончатся попытки.
+// Возвращает ошибки всех попыток, объединенные через errors.Join.
+// Ожидание между попытками прерывается отменой ctx.
+func (b Backoff) Retry(ctx context.Context, fn func(ctx context.Context) error) error {
+	attempts := max(b.Attempts, 1)
+	errs := make([]error, 0, attempts)
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		errs = append(errs, fmt.Errorf("попытка %d: %w", attempt, err))
+		if attempt >= attempts {
+			return errors.Join(errs...)
+		}
+
+		timer := time.NewTimer(b.Delay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(append(errs, ctx.Err())...)
+		case <-timer.C:
+		}
+	}
+}
+
+// Backoff возвращает политику переподключения с постоянной задержкой AttemptDelay
+func (d Database) Backoff() Backoff {
+	return Backoff{
+		Attempts:   d.MaxAttempts,
+		Initial:    d.AttemptDelay,
+		Max:        d.AttemptDelay,
+		Multiplier: 1,
+	}
+}
+
+// Backoff возвращает политику повторной отправки.
+// Задержки соответствуют значениям по умолчанию kafka-go (100ms - 1s).
+func (p KafkaProducer) Backoff() Backoff {
+	return Backoff{
+		Attempts:   p.MaxAttempts,
+		Initial:    100 * time.Millisecond,
+		Max:        time.Second,
+		Multiplier: 2,
+	}
+}
+
+// Backoff возвращает политику повторных операций консьюмера.
+// Задержки соответствуют значениям по умолчанию kafka-go (100ms - 1s).
+func (c KafkaConsumer) Backoff() Backoff {
+	return Backoff{
+		Attempts:   c.MaxAttempts,
+		Initial:    100 * time.Millisecond,
+		Max:        time.Second,
+		Multiplier: 2,
+	}
+}
+
+// Backoff возвращает политику переподключения из параметров Connect*
+func (c GrpcClient) Backoff() Backoff {
+	return Backoff{
+		Attempts:   c.ConnectMaxAttempts,
+		Initial:    c.ConnectInitialBackoff,
+		Max:        c.ConnectMaxBackoff,
+		Multiplier: c.ConnectBackoffMultiplier,
+	}
+}
@@ -0,0 +1,81 @@
+package configo
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff Backoff
+		attempt int
+		want    time.Duration
+	}{
+		{"первая попытка", Backoff{Initial: 100 * time.Millisecond, Multiplier: 2}, 1, 100 * time.Millisecond},
+		{"рост", Backoff{Initial: 100 * time.Millisecond, Multiplier: 2}, 4, 800 * time.Millisecond},
+		{"ограничение max", Backoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2}, 10, time.Second},
+		{"постоянная задержка", Backoff{Initial: time.Second, Multiplier: 1}, 50, time.Second},
+		{"переполнение без max", Backoff{Initial: 100 * time.Millisecond, Multiplier: 2}, 40, math.MaxInt64},
+		{"бесконечность без max", Backoff{Initial: 100 * time.Millisecond, Multiplier: 2}, 5000, math.MaxInt64},
+		{"нулевая задержка", Backoff{Multiplier: 2}, 5000, 0},
+		{"номер попытки меньше 1", Backoff{Initial: time.Second, Multiplier: 2}, 0, time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := tt.backoff.Delay(tt.attempt); got != tt.want {
+			t.Errorf("%s: Delay(%d) = %s, want %s", tt.name, tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestBackoffDelayJitter(t *testing.T) {
+	b := Backoff{Initial: time.Second, Multiplier: 1, Jitter: 0.5}
+	for range 100 {
+		if d := b.Delay(1); d < 500*time.Millisecond || d > 1500*time.Millisecond {
+			t.Fatalf("Delay() = %s вне диапазона jitter", d)
+		}
+	}
+
+	huge := Backoff{Initial: time.Second, Multiplier: 2, Jitter: 0.5}
+	if d := huge.Delay(100); d <= 0 {
+		t.Errorf("Delay() = %s, переполнение после jitter", d)
+	}
+}
+
+func TestBackoffRetry(t *testing.T) {
+	b := Backoff{Attempts: 3, Initial: time.Millisecond, Multiplier: 1}
+
+	calls := 0
+	err := b.Retry(context.Background(), func(context.Context) error {
+		calls++
+		if calls < 2 {
+			return errors.New("fail")
+		}
+		return nil
+	})
+	if err != nil || calls != 2 {
+		t.Errorf("Retry() = %v, calls = %d", err, calls)
+	}
+
+	calls = 0
+	err = b.Retry(context.Background(), func(context.Context) error {
+		calls++
+		return errors.New("fail")
+	})
+	if calls != 3 || err == nil || !strings.Contains(err.Error(), "попытка 3: fail") {
+		t.Errorf("Retry() = %v, calls = %d", err, calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	slow := Backoff{Attempts: 3, Initial: time.Hour, Multiplier: 1}
+	err = slow.Retry(ctx, func(context.Context) error { return errors.New("fail") })
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Retry() = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,167 @@
+package configo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ByteSize размер в байтах. Принимает число байт или значение с единицей измерения:
+// KB, MB, GB, TB (степени 1000) и KiB, MiB, GiB, TiB (степени 1024), например "64KB" или "4MiB"
+type ByteSize int64
+
+const (
+	Byte ByteSize = 1
+
+	KB = 1000 * Byte
+	MB = 1000 * KB
+	GB = 1000 * MB
+	TB = 1000 * GB
+
+	KiB = 1024 * Byte
+	MiB = 1024 * KiB
+	GiB = 1024 * MiB
+	TiB = 1024 * GiB
+)
+
+var byteUnits = []struct {
+	name string
+	size ByteSize
+}{
+	{"TiB", TiB}, {"GiB", GiB}, {"MiB", MiB}, {"KiB", KiB},
+	{"TB", TB}, {"GB", GB}, {"MB", MB}, {"KB", KB},
+	{"B", Byte},
+}
+
+// ParseByteSize разбирает размер вида "10MB", "4MiB", "1.5GB" или "1024"
+func ParseByteSize(value string) (ByteSize, error) {
+	s := strings.TrimSpace(value)
+	number := strings.TrimRight(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ ")
+	unit := strings.TrimSpace(s[len(number):])
+
+	n, err := strconv.ParseFloat(number, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("некорректный размер %q: ожидается число с единицей измерения, например 10MB или 4MiB", value)
+	}
+	tooLarge := fmt.Errorf("некорректный размер %q: слишком большое значение", value)
+
+	multiplier := Byte
+	if unit != "" {
+		found := false
+		for _, u := range byteUnits {
+			if strings.EqualFold(unit, u.name) {
+				multiplier, found = u.size, true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("некорректный размер %q: неизвестная единица %q", value, unit)
+		}
+	}
+
+	// Целые значения считаются без float64, чтобы не терять точность у больших чисел
+	if whole, err := strconv.ParseInt(number, 10, 64); err == nil {
+		if whole > math.MaxInt64/int64(multiplier) {
+			return 0, tooLarge
+		}
+		return ByteSize(whole) * multiplier, nil
+	} else if errors.Is(err, strconv.ErrRange) {
+		return 0, tooLarge
+	}
+
+	// float64(math.MaxInt64) равно 2^63, само это значение в int64 уже не помещается
+	size := n * float64(multiplier)
+	if size >= math.MaxInt64 {
+		return 0, tooLarge
+	}
+
+	return ByteSize(math.Round(size)), nil
+}
+
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	size, err := ParseByteSize(string(text))
+	if err != nil {
+		return err
+	}
+
+	*b = size
+	return nil
+}
+
+func (b *ByteSize) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalScalar(node, b)
+}
+
+func (b *ByteSize) UnmarshalJSON(data []byte) error {
+	return b.UnmarshalText([]byte(strings.Trim(string(data), `"`)))
+}
+
+func (b ByteSize) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// String возвращает размер в наибольшей единице, в которой он выражается целым числом
+func (b ByteSize) String() string {
+	if b == 0 {
+		return "0B"
+	}
+
+	for _, u := range byteUnits {
+		if b%u.size == 0 {
+			return strconv.FormatInt(int64(b/u.size), 10) + u.name
+		}
+	}
+
+	return strconv.FormatInt(int64(b), 10) + "B"
+}
+
+func (b ByteSize) Int() int {
+	return int(b)
+}
+
+func (b ByteSize) Int64() int64 {
+	return int64(b)
+}
+
+// ByteSizeMB размер, в котором число без единицы измерения означает мегабайты (MiB), а не байты.
+// Используется для полей, которые раньше задавались числом мегабайт, например logger.maxSize: 100
+type ByteSizeMB ByteSize
+
+func (b *ByteSizeMB) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	if s != "" && strings.Trim(s, "0123456789.") == "" {
+		s += "MiB"
+	}
+
+	size, err := ParseByteSize(s)
+	if err != nil {
+		return err
+	}
+
+	*b = ByteSizeMB(size)
+	return nil
+}
+
+func (b *ByteSizeMB) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalScalar(node, b)
+}
+
+func (b *ByteSizeMB) UnmarshalJSON(data []byte) error {
+	return b.UnmarshalText([]byte(strings.Trim(string(data), `"`)))
+}
+
+func (b ByteSizeMB) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+func (b ByteSizeMB) String() string {
+	return ByteSize(b).String()
+}
+
+func (b ByteSizeMB) ByteSize() ByteSize {
+	return ByteSize(b)
+}
@@ -0,0 +1,117 @@
+package configo
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    ByteSize
+		wantErr string
+	}{
+		{"0", 0, ""},
+		{"1024", KiB, ""},
+		{"10MB", 10 * MB, ""},
+		{"4MiB", 4 * MiB, ""},
+		{"4 mib", 4 * MiB, ""},
+		{"1.5GB", 1500 * MB, ""},
+		{"512B", 512, ""},
+		{"9223372036854775807", math.MaxInt64, ""},
+		{"8388607TiB", 8388607 * TiB, ""},
+		{"9223372036854775808", 0, "слишком большое значение"},
+		{"8388608TiB", 0, "слишком большое значение"},
+		{"9.3EB", 0, "неизвестная единица"},
+		{"9300000TB", 0, "слишком большое значение"},
+		{"9223372.1TB", 0, "слишком большое значение"},
+		{"-1MB", 0, "ожидается число"},
+		{"MB", 0, "ожидается число"},
+		{"", 0, "ожидается число"},
+		{"10XB", 0, "неизвестная единица"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseByteSize(tt.value)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseByteSize(%q) = %s, error = %v, want containing %q", tt.value, got, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseByteSize(%q) = %d, %v, want %d", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestByteSizeString(t *testing.T) {
+	tests := []struct {
+		size ByteSize
+		want string
+	}{
+		{0, "0B"},
+		{512, "512B"},
+		{KiB, "1KiB"},
+		{10 * MiB, "10MiB"},
+		{1500 * MB, "1500MB"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.size.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+		parsed, err := ParseByteSize(tt.size.String())
+		if err != nil || parsed != tt.size {
+			t.Errorf("ParseByteSize(%q) = %d, %v, want %d", tt.size.String(), parsed, err, tt.size)
+		}
+	}
+}
+
+func TestByteSizeUnmarshalYAML(t *testing.T) {
+	var cfg struct {
+		MaxSize ByteSize `yaml:"maxSize"`
+	}
+	if err := yaml.Unmarshal([]byte("maxSize: 10MiB"), &cfg); err != nil || cfg.MaxSize != 10*MiB {
+		t.Errorf("maxSize = %s, %v", cfg.MaxSize, err)
+	}
+
+	err := yaml.Unmarshal([]byte("maxSize: 10XB"), &cfg)
+	if err == nil || !strings.Contains(err.Error(), "строка 1:") {
+		t.Errorf("error = %v, want line number", err)
+	}
+}
+
+func TestByteSizeMBUnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		yaml    string
+		want    ByteSizeMB
+		wantErr string
+	}{
+		{"maxSize: 100", ByteSizeMB(100 * MiB), ""},
+		{"maxSize: 1.5", ByteSizeMB(1536 * KiB), ""},
+		{"maxSize: 10MB", ByteSizeMB(10 * MB), ""},
+		{"maxSize: 512KiB", ByteSizeMB(512 * KiB), ""},
+		{"maxSize: 10XB", 0, "строка 1:"},
+		{"maxSize: 9000000000000", 0, "слишком большое значение"},
+	}
+
+	for _, tt := range tests {
+		var cfg struct {
+			MaxSize ByteSizeMB `yaml:"maxSize"`
+		}
+		err := yaml.Unmarshal([]byte(tt.yaml), &cfg)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: error = %v, want containing %q", tt.yaml, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || cfg.MaxSize != tt.want {
+			t.Errorf("%s: got %s, %v, want %s", tt.yaml, cfg.MaxSize, err, tt.want)
+		}
+	}
+}
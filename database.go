@@ -0,0 +1,292 @@
+package configo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// databaseDefaultPorts порты по умолчанию для Type
+var databaseDefaultPorts = map[string]Port{
+	"postgres":   5432,
+	"postgresql": 5432,
+	"pgx":        5432,
+	"mysql":      3306,
+}
+
+func (d *Database) loadEnv(prefix string) error {
+	loadURLEnv(&d.URL, prefix)
+	return nil
+}
+
+func (d *Database) prepare(lc *loadContext) error {
+	if err := d.parseURL(); err != nil {
+		return err
+	}
+
+	if d.Port == 0 {
+		d.Port = databaseDefaultPorts[d.Type]
+	}
+
+	if d.MigrationPath != "" && !filepath.IsAbs(d.MigrationPath) {
+		d.MigrationPath = filepath.Join(lc.dir, d.MigrationPath)
+	}
+
+	for i := range d.Replicas {
+		if d.Replicas[i].Port == 0 {
+			d.Replicas[i].Port = d.Port
+		}
+		if d.Replicas[i].Weight == 0 {
+			d.Replicas[i].Weight = 1
+		}
+	}
+
+	return nil
+}
+
+func (d *Database) parseURL() error {
+	if d.URL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(d.URL)
+	if err != nil {
+		return fieldError("url", err)
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return fieldErrorf("url", "ожидается схема и хост, например postgres://host:5432/name")
+	}
+
+	port, err := urlPort(u)
+	if err != nil {
+		return fieldError("url", err)
+	}
+
+	password, _ := u.User.Password()
+	query := u.Query()
+
+	return errors.Join(
+		mergeField(&d.Type, u.Scheme, "type"),
+		mergeField(&d.Host, u.Hostname(), "host"),
+		mergeField(&d.Port, port, "port"),
+		mergeField(&d.Name, strings.TrimPrefix(u.Path, "/"), "name"),
+		mergeField(&d.User, u.User.Username(), "user"),
+		mergeField(&d.Password, Secret(password), "password"),
+		mergeField(&d.SSLMode, query.Get("sslmode"), "sslMode"),
+		mergeField(&d.SSLRootCert, query.Get("sslrootcert"), "sslRootCert"),
+		mergeField(&d.SSLCert, query.Get("sslcert"), "sslCert"),
+		mergeField(&d.SSLKey, query.Get("sslkey"), "sslKey"),
+	)
+}
+
+func (d *Database) Validate() error {
+	var errs []error
+
+	if d.Type == "" {
+		errs = append(errs, requiredError("type"))
+	}
+	if d.Host == "" {
+		errs = append(errs, requiredError("host"))
+	}
+	if d.Port == 0 {
+		errs = append(errs, requiredError("port"))
+	}
+	if d.Name == "" {
+		errs = append(errs, requiredError("name"))
+	}
+	if d.User == "" {
+		errs = append(errs, requiredError("user"))
+	}
+	if d.Password == "" {
+		errs = append(errs, requiredError("password"))
+	}
+
+	if d.MigrationPath == "" {
+		errs = append(errs, requiredError("migrationPath"))
+	} else if _, err := d.Migrations(); err != nil {
+		errs = append(errs, fieldError("migrationPath", err))
+	}
+
+	if d.MaxOpenConns < 0 {
+		errs = append(errs, fieldErrorf("maxOpenConns", "не может быть отрицательным"))
+	}
+	if d.MaxOpenConns > 0 && d.MaxIdleConns > d.MaxOpenConns {
+		errs = append(errs, fieldErrorf("maxIdleConns", "%d превышает maxOpenConns (%d)", d.MaxIdleConns, d.MaxOpenConns))
+	}
+	if d.StatementTimeout < 0 {
+		errs = append(errs, fieldErrorf("statementTimeout", "не может быть отрицательным"))
+	}
+
+	if d.SSLMode != "" && !slices.Contains(sslModes, d.SSLMode) {
+		errs = append(errs, fieldErrorf("sslMode", "некорректное значение %q, допустимо: %v", d.SSLMode, sslModes))
+	}
+	if (d.SSLCert == "") != (d.SSLKey == "") {
+		errs = append(errs, fieldErrorf("sslCert", "sslCert и sslKey задаются только вместе"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r *DatabaseReplica) Validate() error {
+	var errs []error
+
+	if r.Host == "" {
+		errs = append(errs, requiredError("host"))
+	}
+	if r.Weight < 0 {
+		errs = append(errs, fieldErrorf("weight", "не может быть отрицательным"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// DSN возвращает строку подключения к основной базе
+func (d Database) DSN() string {
+	return d.dsn(d.Addr())
+}
+
+// ReplicaDSNs возвращает строки подключения к репликам в порядке объявления
+func (d Database) ReplicaDSNs() []string {
+	dsns := make([]string, 0, len(d.Replicas))
+	for _, r := range d.Replicas {
+		dsns = append(dsns, d.dsn(r.Addr()))
+	}
+
+	return dsns
+}
+
+// dsn собирает строку подключения в формате драйвера по Type.
+// Для mysql используется формат go-sql-driver, для остальных - url.
+func (d Database) dsn(addr string) string {
+	switch d.Type {
+	case "mysql":
+		return fmt.Sprintf("%s:%s@tcp(%s)/%s", d.User, d.Password.Value(), addr, d.Name)
+	case "postgres", "postgresql", "pgx":
+		query := url.Values{}
+		setNotEmpty(query, "search_path", d.Schema)
+		setNotEmpty(query, "sslmode", d.SSLMode)
+		setNotEmpty(query, "sslrootcert", d.SSLRootCert)
+		setNotEmpty(query, "sslcert", d.SSLCert)
+		setNotEmpty(query, "sslkey", d.SSLKey)
+		if d.StatementTimeout > 0 {
+			query.Set("statement_timeout", strconv.FormatInt(d.StatementTimeout.Milliseconds(), 10))
+		}
+
+		return d.urlDSN("postgres", addr, query)
+	default:
+		return d.urlDSN(d.Type, addr, nil)
+	}
+}
+
+func (d Database) urlDSN(scheme, addr string, query url.Values) string {
+	u := url.URL{
+		Scheme:   scheme,
+		User:     url.UserPassword(d.User, d.Password.Value()),
+		Host:     addr,
+		Path:     "/" + d.Name,
+		RawQuery: query.Encode(),
+	}
+
+	return u.String()
+}
+
+func setNotEmpty(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+// ReplicaPicker выбирает реплику для чтения по весам (smooth weighted round-robin).
+// Без реплик всегда возвращает основную базу. Безопасен для конкурентного использования.
+type ReplicaPicker struct {
+	mu      sync.Mutex
+	dsns    []string
+	weights []int
+	current []int
+	total   int
+}
+
+func NewReplicaPicker(d Database) *ReplicaPicker {
+	p := &ReplicaPicker{}
+
+	for i, dsn := range d.ReplicaDSNs() {
+		weight := max(d.Replicas[i].Weight, 1)
+		p.dsns = append(p.dsns, dsn)
+		p.weights = append(p.weights, weight)
+		p.total += weight
+	}
+
+	if len(p.dsns) == 0 {
+		p.dsns = []string{d.DSN()}
+		p.weights = []int{1}
+		p.total = 1
+	}
+
+	p.current = make([]int, len(p.dsns))
+
+	return p
+}
+
+// Next возвращает строку подключения к следующей реплике
+func (p *ReplicaPicker) Next() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	best := 0
+	for i, weight := range p.weights {
+		p.current[i] += weight
+		if p.current[i] > p.current[best] {
+			best = i
+		}
+	}
+	p.current[best] -= p.total
+
+	return p.dsns[best]
+}
+
+// Ping проверяет доступность основной базы через зарегистрированный драйвер driverName.
+// Попытки повторяются согласно MaxAttempts и AttemptDelay,
+// при неудаче возвращается ошибка с причиной каждой попытки.
+func (d Database) Ping(ctx context.Context, driverName string) error {
+	db, err := sql.Open(driverName, d.DSN())
+	if err != nil {
+		return fmt.Errorf("открытие соединения с %s: %w", d.Host, err)
+	}
+	defer db.Close()
+
+	err = d.Backoff().Retry(ctx, func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("база %s недоступна: %w", d.Host, err)
+	}
+
+	return nil
+}
+
+// Apply применяет настройки пула соединений к db
+func (d Database) Apply(db *sql.DB) {
+	db.SetMaxOpenConns(d.MaxOpenConns)
+	db.SetMaxIdleConns(d.MaxIdleConns)
+	db.SetConnMaxLifetime(d.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(d.ConnMaxIdleTime)
+}
+
+// urlPort возвращает порт из url или 0, если он не указан
+func urlPort(u *url.URL) (Port, error) {
+	if u.Port() == "" {
+		return 0, nil
+	}
+
+	return ParsePort(u.Port())
+}
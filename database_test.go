@@ -0,0 +1,211 @@
+package configo
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeDriver драйвер database/sql, который отказывает в подключении первые fails раз
+type fakeDriver struct {
+	mu    sync.Mutex
+	fails int
+	opens []time.Time
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.opens = append(d.opens, time.Now())
+	if len(d.opens) <= d.fails {
+		return nil, fmt.Errorf("отказ %d", len(d.opens))
+	}
+
+	return fakeConn{}, nil
+}
+
+func (d *fakeDriver) attempts() []time.Time {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.opens
+}
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("не поддерживается")
+}
+func (fakeConn) Close() error { return nil }
+func (fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("не поддерживается")
+}
+
+var fakeDrivers atomic.Int64
+
+// registerFakeDriver регистрирует новый драйвер под уникальным именем:
+// sql.Register паникует при повторной регистрации, в том числе при go test -count
+func registerFakeDriver(fails int) (*fakeDriver, string) {
+	d := &fakeDriver{fails: fails}
+	name := fmt.Sprintf("fake-%d", fakeDrivers.Add(1))
+	sql.Register(name, d)
+
+	return d, name
+}
+
+func TestDatabasePing(t *testing.T) {
+	tests := []struct {
+		name         string
+		fails        int
+		maxAttempts  int
+		wantAttempts int
+		wantErr      []string
+	}{
+		{"сразу доступна", 0, 3, 1, nil},
+		{"доступна со второй попытки", 1, 3, 2, nil},
+		{"недоступна", 5, 3, 3, []string{"попытка 1: отказ 1", "попытка 2: отказ 2", "попытка 3: отказ 3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			drv, name := registerFakeDriver(tt.fails)
+			const delay = 20 * time.Millisecond
+			d := Database{Type: "fake", Host: "db", Port: 5432, MaxAttempts: tt.maxAttempts, AttemptDelay: delay}
+
+			err := d.Ping(context.Background(), name)
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			for _, want := range tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), want) {
+					t.Errorf("error = %v, want containing %q", err, want)
+				}
+			}
+
+			opens := drv.attempts()
+			if len(opens) != tt.wantAttempts {
+				t.Fatalf("attempts = %d, want %d", len(opens), tt.wantAttempts)
+			}
+			for i := 1; i < len(opens); i++ {
+				if gap := opens[i].Sub(opens[i-1]); gap < delay {
+					t.Errorf("пауза перед попыткой %d = %s, want >= %s", i+1, gap, delay)
+				}
+			}
+		})
+	}
+}
+
+func TestDatabasePingContextCancel(t *testing.T) {
+	drv, name := registerFakeDriver(100)
+	d := Database{Type: "fake", Host: "db", Port: 5432, MaxAttempts: 5, AttemptDelay: time.Hour}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := d.Ping(ctx, name)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Ping ждал %s после отмены ctx", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "отказ 1") {
+		t.Errorf("error = %v, want отказ 1 и context.DeadlineExceeded", err)
+	}
+	if n := len(drv.attempts()); n != 1 {
+		t.Errorf("attempts = %d, want 1", n)
+	}
+}
+
+func TestDatabasePingUnknownDriver(t *testing.T) {
+	err := Database{Host: "db", MaxAttempts: 1}.Ping(context.Background(), "unknown")
+	if err == nil || !strings.Contains(err.Error(), "открытие соединения") {
+		t.Errorf("error = %v, want открытие соединения", err)
+	}
+}
+
+func TestDatabaseParseURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		database Database
+		want     Database
+		wantErr  string
+	}{
+		{
+			name:     "postgres",
+			database: Database{URL: "postgres://app:secret@db:5433/app?sslmode=require"},
+			want:     Database{Type: "postgres", Host: "db", Port: 5433, Name: "app", User: "app", Password: "secret", SSLMode: "require"},
+		},
+		{
+			name:     "порт по умолчанию postgres",
+			database: Database{URL: "postgres://app:secret@db/app"},
+			want:     Database{Type: "postgres", Host: "db", Port: 5432, Name: "app", User: "app", Password: "secret"},
+		},
+		{
+			name:     "порт по умолчанию mysql",
+			database: Database{URL: "mysql://app:secret@db/app"},
+			want:     Database{Type: "mysql", Host: "db", Port: 3306, Name: "app", User: "app", Password: "secret"},
+		},
+		{
+			name:     "порт из поля",
+			database: Database{URL: "postgres://app:secret@db/app", Port: 6432},
+			want:     Database{Type: "postgres", Host: "db", Port: 6432, Name: "app", User: "app", Password: "secret"},
+		},
+		{
+			name:     "совпадает с полями",
+			database: Database{URL: "postgres://app:secret@db:5432/app", Host: "db", User: "app"},
+			want:     Database{Type: "postgres", Host: "db", Port: 5432, Name: "app", User: "app", Password: "secret"},
+		},
+		{
+			name:     "противоречит полям",
+			database: Database{URL: "postgres://app:secret@db:5432/app", Host: "other", Port: 5433},
+			wantErr:  "host: значение противоречит указанному в url",
+		},
+		{
+			name:     "противоречит порту",
+			database: Database{URL: "postgres://app:secret@db:5432/app", Port: 5433},
+			wantErr:  "port: значение противоречит указанному в url",
+		},
+		{name: "без схемы", database: Database{URL: "db:5432/app"}, wantErr: "url"},
+		{name: "некорректный порт", database: Database{URL: "postgres://db:70000/app"}, wantErr: "url"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.database.prepare(&loadContext{})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := tt.database
+			got.URL = ""
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDatabasePasswordMasked(t *testing.T) {
+	d := Database{Type: "postgres", Host: "db", Port: 5432, Name: "app", User: "app", Password: "p@ss"}
+
+	if dsn := d.DSN(); !strings.Contains(dsn, "app:p%40ss@db:5432") {
+		t.Errorf("DSN() = %s, want real password", dsn)
+	}
+	if dump := fmt.Sprintf("%+v", d); strings.Contains(dump, "p@ss") {
+		t.Errorf("%%+v раскрывает пароль: %s", dump)
+	}
+}
@@ -0,0 +1,178 @@
+package configo
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	Day  = 24 * time.Hour
+	Week = 7 * Day
+)
+
+// Duration длительность, которая помимо формата time.ParseDuration принимает
+// дни и недели ("7d", "2w3d12h") и формат ISO-8601 ("P7D", "PT1H30M")
+type Duration time.Duration
+
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond, // U+00B5
+	"μs": time.Microsecond, // U+03BC
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  Day,
+	"w":  Week,
+}
+
+// ParseDuration разбирает длительность в формате Go с днями и неделями или в формате ISO-8601
+func ParseDuration(value string) (Duration, error) {
+	s := strings.TrimSpace(value)
+
+	sign := time.Duration(1)
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		sign, s = -1, rest
+	} else {
+		s = strings.TrimPrefix(s, "+")
+	}
+
+	// Раньше часть полей, например logger.maxAge, задавалась числом без единицы
+	if s != "" && s != "0" && strings.Trim(s, "0123456789.") == "" {
+		n := strings.TrimSpace(value)
+		return 0, fmt.Errorf("некорректная длительность %q: не указана единица измерения, укажите ее, например %sd для дней или %ss для секунд", value, n, n)
+	}
+
+	var (
+		d   time.Duration
+		err error
+	)
+	if strings.HasPrefix(s, "P") {
+		d, err = parseISODuration(s[1:])
+	} else {
+		d, err = parseUnitsDuration(s, durationUnits)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("некорректная длительность %q: %w", value, err)
+	}
+
+	return Duration(sign * d), nil
+}
+
+// parseUnitsDuration разбирает последовательность чисел с единицами, например 1d12h30m
+func parseUnitsDuration(s string, units map[string]time.Duration) (time.Duration, error) {
+	if s == "0" {
+		return 0, nil
+	}
+	if s == "" {
+		return 0, fmt.Errorf("пустое значение")
+	}
+
+	var total time.Duration
+	for s != "" {
+		i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+		switch {
+		case i < 0:
+			return 0, fmt.Errorf("не указана единица измерения")
+		case i == 0:
+			return 0, fmt.Errorf("ожидается число перед единицей измерения")
+		}
+		number := s[:i]
+		s = s[i:]
+
+		j := strings.IndexFunc(s, func(r rune) bool { return r >= '0' && r <= '9' || r == '.' })
+		if j < 0 {
+			j = len(s)
+		}
+		unit, ok := units[s[:j]]
+		if !ok {
+			return 0, fmt.Errorf("неизвестная единица %q", s[:j])
+		}
+		s = s[j:]
+
+		n, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return 0, fmt.Errorf("некорректное число %q", number)
+		}
+		part := n * float64(unit)
+		if part >= math.MaxInt64 || float64(total)+part >= math.MaxInt64 {
+			return 0, fmt.Errorf("слишком большое значение")
+		}
+		total += time.Duration(part)
+	}
+
+	return total, nil
+}
+
+// parseISODuration разбирает ISO-8601 без префикса P: nWnDTnHnMnS.
+// Годы и месяцы не поддерживаются, так как не имеют фиксированной длины.
+func parseISODuration(s string) (time.Duration, error) {
+	date, clock, hasTime := strings.Cut(s, "T")
+	if date == "" && clock == "" {
+		return 0, fmt.Errorf("пустая длительность ISO-8601")
+	}
+
+	var total time.Duration
+	if date != "" {
+		d, err := parseUnitsDuration(date, map[string]time.Duration{"W": Week, "D": Day})
+		if err != nil {
+			return 0, fmt.Errorf("%w (годы и месяцы не поддерживаются)", err)
+		}
+		total += d
+	}
+
+	if hasTime {
+		d, err := parseUnitsDuration(clock, map[string]time.Duration{"H": time.Hour, "M": time.Minute, "S": time.Second})
+		if err != nil {
+			return 0, err
+		}
+		if d > math.MaxInt64-total {
+			return 0, fmt.Errorf("слишком большое значение")
+		}
+		total += d
+	}
+
+	return total, nil
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+	return nil
+}
+
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalScalar(node, d)
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	return d.UnmarshalText([]byte(strings.Trim(string(data), `"`)))
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// String возвращает длительность в днях, если она делится на сутки, иначе в формате time.Duration
+func (d Duration) String() string {
+	td := time.Duration(d)
+	if td != 0 && td%Day == 0 {
+		return strconv.FormatInt(int64(td/Day), 10) + "d"
+	}
+
+	return td.String()
+}
+
+func (d Duration) Duration() time.Duration {
+	return time.Duration(d)
+}
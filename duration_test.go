@@ -0,0 +1,92 @@
+package configo
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr string
+	}{
+		{"0", 0, ""},
+		{"1h30m", 90 * time.Minute, ""},
+		{"7d", Week, ""},
+		{"2w3d12h", 2*Week + 3*Day + 12*time.Hour, ""},
+		{"1.5d", 36 * time.Hour, ""},
+		{"-1d", -Day, ""},
+		{"300ms", 300 * time.Millisecond, ""},
+		{" 24h ", Day, ""},
+		{"P7D", Week, ""},
+		{"P1W2D", Week + 2*Day, ""},
+		{"PT1H30M", 90 * time.Minute, ""},
+		{"P1DT12H", 36 * time.Hour, ""},
+		{"365", 0, "например 365d"},
+		{"", 0, "пустое значение"},
+		{"10x", 0, "неизвестная единица"},
+		{"d", 0, "ожидается число"},
+		{"P1Y", 0, "годы и месяцы не поддерживаются"},
+		{"P", 0, "пустая длительность"},
+		{"+1h", time.Hour, ""},
+		{"1μs", time.Microsecond, ""},
+		{"1µs", time.Microsecond, ""},
+		{"1000000w", 0, "слишком большое значение"},
+		{"P15000WT100000H", 0, "слишком большое значение"},
+		{"P15000W", 15000 * Week, ""},
+		{"100000w100000w", 0, "слишком большое значение"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.value)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseDuration(%q) error = %v, want containing %q", tt.value, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got.Duration() != tt.want {
+			t.Errorf("ParseDuration(%q) = %s, %v, want %s", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestDurationString(t *testing.T) {
+	tests := []struct {
+		d    Duration
+		want string
+	}{
+		{0, "0s"},
+		{Duration(Week), "7d"},
+		{Duration(36 * time.Hour), "36h0m0s"},
+		{Duration(90 * time.Minute), "1h30m0s"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.d.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+		parsed, err := ParseDuration(tt.d.String())
+		if err != nil || parsed != tt.d {
+			t.Errorf("ParseDuration(%q) = %s, %v, want %s", tt.d.String(), parsed, err, tt.d)
+		}
+	}
+}
+
+func TestDurationUnmarshalYAML(t *testing.T) {
+	var cfg struct {
+		MaxAge Duration `yaml:"maxAge"`
+	}
+	if err := yaml.Unmarshal([]byte("maxAge: 30d"), &cfg); err != nil || cfg.MaxAge.Duration() != 30*Day {
+		t.Errorf("maxAge = %s, %v", cfg.MaxAge, err)
+	}
+
+	err := yaml.Unmarshal([]byte("\nmaxAge: 365"), &cfg)
+	if err == nil || !strings.Contains(err.Error(), "строка 2:") || !strings.Contains(err.Error(), "365d") {
+		t.Errorf("error = %v, want line number and unit hint", err)
+	}
+}
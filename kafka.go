@@ -0,0 +1,258 @@
+package configo
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	KafkaSASLPlain       = "PLAIN"
+	KafkaSASLScramSHA256 = "SCRAM-SHA-256"
+	KafkaSASLScramSHA512 = "SCRAM-SHA-512"
+)
+
+func (s *KafkaSASL) Validate() error {
+	switch s.Mechanism {
+	case "":
+		return nil
+	case KafkaSASLPlain, KafkaSASLScramSHA256, KafkaSASLScramSHA512:
+	default:
+		return fieldErrorf("mechanism", "некорректное значение %q, допустимо: %s, %s, %s",
+			s.Mechanism, KafkaSASLPlain, KafkaSASLScramSHA256, KafkaSASLScramSHA512)
+	}
+
+	var errs []error
+
+	if s.Username == "" {
+		errs = append(errs, requiredError("username"))
+	}
+	if s.Password == "" {
+		errs = append(errs, requiredError("password"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// loadEnv подставляет в записи общие brokers и security. Это происходит до чтения переменных
+// окружения самих записей, поэтому блок security.sasl или security.tls наследуется, только если
+// он не задан у записи ни в yaml, ни в окружении: частичное переопределение не смешивается с общим
+func (k *Kafka) loadEnv(prefix string) error {
+	producers := joinEnv(prefix, envName("producers"))
+	for name, p := range k.Producers {
+		p.Brokers, p.Security = k.inherit(p.Brokers, p.Security, joinEnv(producers, envName(name)))
+		k.Producers[name] = p
+	}
+
+	consumers := joinEnv(prefix, envName("consumers"))
+	for name, c := range k.Consumers {
+		c.Brokers, c.Security = k.inherit(c.Brokers, c.Security, joinEnv(consumers, envName(name)))
+		k.Consumers[name] = c
+	}
+
+	return nil
+}
+
+// inherit подставляет общие brokers и security, если в записи с префиксом окружения prefix они не заданы
+func (k Kafka) inherit(brokers []string, security KafkaSecurity, prefix string) ([]string, KafkaSecurity) {
+	if len(brokers) == 0 {
+		brokers = slices.Clone(k.Brokers)
+	}
+	if security.SASL == (KafkaSASL{}) && !hasEnvPrefix(joinEnv(prefix, "SECURITY_SASL")) {
+		security.SASL = k.Security.SASL
+	}
+	if security.TLS == (ClientTLS{}) && !hasEnvPrefix(joinEnv(prefix, "SECURITY_TLS")) {
+		security.TLS = k.Security.TLS
+	}
+
+	return brokers, security
+}
+
+// hasEnvPrefix сообщает, что задана хотя бы одна переменная окружения вида PREFIX_*
+func hasEnvPrefix(prefix string) bool {
+	for _, env := range os.Environ() {
+		if strings.HasPrefix(env, prefix+"_") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TLSConfig собирает *tls.Config для подключения к брокерам. Если TLS выключен, возвращает nil.
+func (s KafkaSecurity) TLSConfig() (*tls.Config, error) {
+	return s.TLS.TLSConfig()
+}
+
+// KafkaAcks уровень подтверждения записи. Принимает имя или число: none (0), leader (1), all (-1)
+type KafkaAcks string
+
+const (
+	KafkaAcksNone   KafkaAcks = "none"
+	KafkaAcksLeader KafkaAcks = "leader"
+	KafkaAcksAll    KafkaAcks = "all"
+)
+
+func (a *KafkaAcks) UnmarshalText(text []byte) error {
+	switch value := strings.ToLower(strings.TrimSpace(string(text))); value {
+	case "0":
+		*a = KafkaAcksNone
+	case "1":
+		*a = KafkaAcksLeader
+	case "-1":
+		*a = KafkaAcksAll
+	default:
+		acks, err := parseEnum(value, KafkaAcksNone, KafkaAcksLeader, KafkaAcksAll)
+		if err != nil {
+			return err
+		}
+		*a = acks
+	}
+
+	return nil
+}
+
+func (a *KafkaAcks) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalScalar(node, a)
+}
+
+// Int возвращает числовое значение для клиента kafka: 0, 1 или -1
+func (a KafkaAcks) Int() int {
+	switch a {
+	case KafkaAcksNone:
+		return 0
+	case KafkaAcksAll:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// KafkaStartOffset позиция чтения для новой группы консьюмеров
+type KafkaStartOffset string
+
+const (
+	KafkaOffsetLatest   KafkaStartOffset = "latest"
+	KafkaOffsetEarliest KafkaStartOffset = "earliest"
+)
+
+func (o *KafkaStartOffset) UnmarshalText(text []byte) error {
+	offset, err := parseEnum(string(text), KafkaOffsetLatest, KafkaOffsetEarliest)
+	if err != nil {
+		return err
+	}
+
+	*o = offset
+	return nil
+}
+
+func (o *KafkaStartOffset) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalScalar(node, o)
+}
+
+// Offset возвращает значение для kafka-go: FirstOffset (-2) для earliest и LastOffset (-1) для latest
+func (o KafkaStartOffset) Offset() int64 {
+	if o == KafkaOffsetEarliest {
+		return -2
+	}
+
+	return -1
+}
+
+// KafkaCompression кодек сжатия сообщений
+type KafkaCompression string
+
+const (
+	KafkaCompressionNone   KafkaCompression = "none"
+	KafkaCompressionGzip   KafkaCompression = "gzip"
+	KafkaCompressionSnappy KafkaCompression = "snappy"
+	KafkaCompressionLz4    KafkaCompression = "lz4"
+	KafkaCompressionZstd   KafkaCompression = "zstd"
+)
+
+func (c *KafkaCompression) UnmarshalText(text []byte) error {
+	compression, err := parseEnum(string(text), KafkaCompressionNone, KafkaCompressionGzip,
+		KafkaCompressionSnappy, KafkaCompressionLz4, KafkaCompressionZstd)
+	if err != nil {
+		return err
+	}
+
+	*c = compression
+	return nil
+}
+
+func (c *KafkaCompression) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalScalar(node, c)
+}
+
+// KafkaBalancer стратегия выбора партиции для сообщения
+type KafkaBalancer string
+
+const (
+	KafkaBalancerHash       KafkaBalancer = "hash"
+	KafkaBalancerRoundRobin KafkaBalancer = "roundrobin"
+	KafkaBalancerLeastBytes KafkaBalancer = "leastbytes"
+)
+
+func (b *KafkaBalancer) UnmarshalText(text []byte) error {
+	balancer, err := parseEnum(string(text), KafkaBalancerHash, KafkaBalancerRoundRobin, KafkaBalancerLeastBytes)
+	if err != nil {
+		return err
+	}
+
+	*b = balancer
+	return nil
+}
+
+func (b *KafkaBalancer) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalScalar(node, b)
+}
+
+func (p *KafkaProducer) Validate() error {
+	var errs []error
+
+	if p.Idempotent && p.RequiredAcks != KafkaAcksAll {
+		errs = append(errs, fieldErrorf("idempotent", "идемпотентная запись требует requiredAcks: all"))
+	}
+	if p.Async && p.RequiredAcks == KafkaAcksAll {
+		errs = append(errs, fieldErrorf("async", "асинхронная запись не дожидается подтверждений, requiredAcks: all не дает гарантий доставки"))
+	}
+	if p.BatchTimeout >= p.WriteTimeout {
+		errs = append(errs, fieldErrorf("batchTimeout", "%s должно быть меньше writeTimeout (%s)", p.BatchTimeout, p.WriteTimeout))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *KafkaConsumer) Validate() error {
+	var errs []error
+
+	if c.MinBytes > c.MaxBytes {
+		errs = append(errs, fieldErrorf("minBytes", "%s больше maxBytes (%s)", c.MinBytes, c.MaxBytes))
+	}
+	if c.HeartbeatInterval >= c.SessionTimeout {
+		errs = append(errs, fieldErrorf("heartbeatInterval", "%s должно быть меньше sessionTimeout (%s)", c.HeartbeatInterval, c.SessionTimeout))
+	}
+	if c.ReadTimeout <= c.MaxWait {
+		errs = append(errs, fieldErrorf("readTimeout", "%s должно быть больше maxWait (%s)", c.ReadTimeout, c.MaxWait))
+	}
+
+	return errors.Join(errs...)
+}
+
+// parseEnum проверяет, что значение входит в список допустимых, без учета регистра
+func parseEnum[T ~string](value string, allowed ...T) (T, error) {
+	value = strings.TrimSpace(value)
+	for _, a := range allowed {
+		if strings.EqualFold(value, string(a)) {
+			return a, nil
+		}
+	}
+
+	return "", fmt.Errorf("некорректное значение %q, допустимо: %v", value, allowed)
+}
@@ -0,0 +1,91 @@
+package configo
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+func (r *KafkaRetry) Validate() error {
+	if !r.Enabled {
+		return nil
+	}
+
+	var errs []error
+
+	if len(r.Delays) == 0 {
+		errs = append(errs, requiredError("delays"))
+	}
+	for i, delay := range r.Delays {
+		if delay <= 0 {
+			errs = append(errs, fieldErrorf("delays["+strconv.Itoa(i)+"]", "должно быть больше нуля"))
+		}
+	}
+
+	if r.MaxRetries < 1 {
+		errs = append(errs, fieldErrorf("maxRetries", "должно быть не меньше 1"))
+	}
+
+	if !strings.Contains(r.TopicTemplate, "{topic}") {
+		errs = append(errs, fieldErrorf("topicTemplate", "шаблон должен содержать {topic}"))
+	}
+	if len(r.Delays) > 1 && !strings.Contains(r.TopicTemplate, "{n}") {
+		errs = append(errs, fieldErrorf("topicTemplate", "при нескольких delays шаблон должен содержать {n}"))
+	}
+	if !strings.Contains(r.DeadLetterTopic, "{topic}") {
+		errs = append(errs, fieldErrorf("deadLetterTopic", "шаблон должен содержать {topic}"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// RetryTopics возвращает retry-топики для исходного топика в порядке задержек
+func (r KafkaRetry) RetryTopics(topic string) []string {
+	topics := make([]string, 0, len(r.Delays))
+	for i := range r.Delays {
+		topics = append(topics, strings.NewReplacer("{topic}", topic, "{n}", strconv.Itoa(i+1)).Replace(r.TopicTemplate))
+	}
+
+	return topics
+}
+
+// DeadLetter возвращает dead-letter топик для исходного топика
+func (r KafkaRetry) DeadLetter(topic string) string {
+	return strings.ReplaceAll(r.DeadLetterTopic, "{topic}", topic)
+}
+
+// checkRetryTopics проверяет, что retry и dead-letter топики консьюмеров объявлены в KafkaTopics.
+// Без секции KafkaTopics проверка пропускается: топики могут создаваться вне сервиса.
+func checkRetryTopics(root reflect.Value) error {
+	declared := make(map[string]bool)
+	hasTopics := false
+
+	_ = walk(root, "", func(v reflect.Value, _ string) error {
+		if topics, ok := v.Interface().(*KafkaTopics); ok {
+			hasTopics = true
+			for _, name := range topics.Names() {
+				declared[name] = true
+			}
+		}
+		return nil
+	})
+
+	return walk(root, "", func(v reflect.Value, _ string) error {
+		consumer, ok := v.Interface().(*KafkaConsumer)
+		if !ok || !consumer.Retry.Enabled || !hasTopics {
+			return nil
+		}
+
+		var errs []error
+		for _, topic := range consumer.Topics {
+			for _, name := range append(consumer.Retry.RetryTopics(topic), consumer.Retry.DeadLetter(topic)) {
+				if !declared[name] {
+					errs = append(errs, fieldErrorf("retry", "топик %q не объявлен в KafkaTopics", name))
+				}
+			}
+		}
+
+		return errors.Join(errs...)
+	})
+}
@@ -0,0 +1,87 @@
+package configo
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestKafkaRetryTopics(t *testing.T) {
+	r := KafkaRetry{
+		Delays:          []Duration{Duration(time.Second), Duration(time.Minute)},
+		TopicTemplate:   "{topic}.retry.{n}",
+		DeadLetterTopic: "{topic}.dlq",
+	}
+
+	if got := strings.Join(r.RetryTopics("orders"), ","); got != "orders.retry.1,orders.retry.2" {
+		t.Errorf("RetryTopics() = %s", got)
+	}
+	if got := r.DeadLetter("orders"); got != "orders.dlq" {
+		t.Errorf("DeadLetter() = %s", got)
+	}
+}
+
+func TestCheckRetryTopics(t *testing.T) {
+	type config struct {
+		Consumer KafkaConsumer `yaml:"consumer"`
+		Topics   *KafkaTopics  `yaml:"topics"`
+	}
+
+	consumer := KafkaConsumer{
+		Topics:            []string{"orders"},
+		HeartbeatInterval: 3 * time.Second,
+		SessionTimeout:    30 * time.Second,
+		MaxWait:           time.Second,
+		ReadTimeout:       30 * time.Second,
+		Retry: KafkaRetry{
+			Enabled:         true,
+			Delays:          []Duration{Duration(time.Second)},
+			MaxRetries:      3,
+			TopicTemplate:   "{topic}.retry.{n}",
+			DeadLetterTopic: "{topic}.dlq",
+		},
+	}
+
+	disabled := consumer
+	disabled.Retry = KafkaRetry{}
+
+	tests := []struct {
+		name    string
+		cfg     config
+		wantErr string
+	}{
+		{
+			name: "все топики объявлены",
+			cfg:  config{Consumer: consumer, Topics: &KafkaTopics{List: []string{"orders", "orders.retry.1", "orders.dlq"}, NumPartitions: 1, ReplicationFactor: 1}},
+		},
+		{
+			name:    "нет dead-letter топика",
+			cfg:     config{Consumer: consumer, Topics: &KafkaTopics{List: []string{"orders", "orders.retry.1"}, NumPartitions: 1, ReplicationFactor: 1}},
+			wantErr: `consumer.retry: топик "orders.dlq" не объявлен`,
+		},
+		{
+			// Топики создаются вне сервиса
+			name: "нет секции KafkaTopics",
+			cfg:  config{Consumer: consumer},
+		},
+		{
+			name: "retry выключен",
+			cfg:  config{Consumer: disabled, Topics: &KafkaTopics{List: []string{"orders"}, NumPartitions: 1, ReplicationFactor: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := finalize(&tt.cfg, &loadContext{})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package configo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestKafkaInheritSecurity(t *testing.T) {
+	shared := KafkaSASL{Mechanism: KafkaSASLPlain, Username: "shared", Password: "shared-secret"}
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		producer KafkaProducer
+		want     KafkaSASL
+		wantErr  string
+	}{
+		{
+			name: "наследуется",
+			want: shared,
+		},
+		{
+			name:     "задан в yaml",
+			producer: KafkaProducer{Security: KafkaSecurity{SASL: KafkaSASL{Mechanism: KafkaSASLScramSHA256, Username: "own", Password: "own-secret"}}},
+			want:     KafkaSASL{Mechanism: KafkaSASLScramSHA256, Username: "own", Password: "own-secret"},
+		},
+		{
+			name: "задан в окружении целиком",
+			env: map[string]string{
+				"KAFKA_PRODUCERS_ORDERS_SECURITY_SASL_MECHANISM": KafkaSASLScramSHA512,
+				"KAFKA_PRODUCERS_ORDERS_SECURITY_SASL_USERNAME":  "own",
+				"KAFKA_PRODUCERS_ORDERS_SECURITY_SASL_PASSWORD":  "own-secret",
+			},
+			want: KafkaSASL{Mechanism: KafkaSASLScramSHA512, Username: "own", Password: "own-secret"},
+		},
+		{
+			name:    "частично задан в окружении",
+			env:     map[string]string{"KAFKA_PRODUCERS_ORDERS_SECURITY_SASL_MECHANISM": KafkaSASLScramSHA512},
+			wantErr: "kafka.producers.orders.security.sasl.username: обязательное поле не заполнено",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			cfg := struct {
+				Kafka Kafka `yaml:"kafka"`
+			}{Kafka{
+				Brokers:   []string{"kafka:9092"},
+				Security:  KafkaSecurity{SASL: shared},
+				Producers: Named[KafkaProducer]{"orders": tt.producer},
+			}}
+
+			err := finalize(&cfg, &loadContext{})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("finalize: %v", err)
+			}
+
+			got := cfg.Kafka.Producers["orders"]
+			if got.Security.SASL != tt.want {
+				t.Errorf("sasl = %+v, want %+v", got.Security.SASL, tt.want)
+			}
+			if len(got.Brokers) != 1 || got.Brokers[0] != "kafka:9092" {
+				t.Errorf("brokers = %v, want [kafka:9092]", got.Brokers)
+			}
+		})
+	}
+}
+
+func TestCheckReplicationFactor(t *testing.T) {
+	type config struct {
+		Kafka       *Kafka      `yaml:"kafka"`
+		KafkaTopics KafkaTopics `yaml:"kafkaTopics"`
+	}
+
+	topics := KafkaTopics{
+		List:              []string{"orders"},
+		NumPartitions:     3,
+		ReplicationFactor: 2,
+		Topics:            []KafkaTopic{{Name: "events", ReplicationFactor: 3}},
+	}
+
+	tests := []struct {
+		name    string
+		kafka   *Kafka
+		wantErr string
+	}{
+		{"брокеров достаточно", &Kafka{Brokers: []string{"k1:9092", "k2:9092", "k3:9092"}}, ""},
+		{"брокеров меньше", &Kafka{Brokers: []string{"k1:9092", "k2:9092"}}, "kafkaTopics.topics[0].replicationFactor: 3 превышает количество брокеров"},
+		{"без общих brokers", &Kafka{}, ""},
+		{"без секции Kafka", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config{Kafka: tt.kafka, KafkaTopics: topics}
+
+			err := finalize(&cfg, &loadContext{})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+			if strings.Contains(err.Error(), "list[0]") {
+				t.Errorf("лишняя ошибка для orders: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package configo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// kafkaTopicName допустимые символы и длина имени топика в Kafka
+var kafkaTopicName = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,249}$`)
+
+func (t *KafkaTopic) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		t.Name = node.Value
+		return nil
+	}
+
+	type plain KafkaTopic
+	return node.Decode((*plain)(t))
+}
+
+// All возвращает все топики: из List и Topics, с подставленными значениями по умолчанию
+func (k KafkaTopics) All() []KafkaTopic {
+	topics := make([]KafkaTopic, 0, len(k.List)+len(k.Topics))
+
+	for _, name := range k.List {
+		topics = append(topics, k.withDefaults(KafkaTopic{Name: name}))
+	}
+	for _, topic := range k.Topics {
+		topics = append(topics, k.withDefaults(topic))
+	}
+
+	return topics
+}
+
+// Names возвращает имена всех топиков
+func (k KafkaTopics) Names() []string {
+	names := make([]string, 0, len(k.List)+len(k.Topics))
+	for _, topic := range k.All() {
+		names = append(names, topic.Name)
+	}
+
+	return names
+}
+
+func (k KafkaTopics) withDefaults(topic KafkaTopic) KafkaTopic {
+	if topic.Partitions == 0 {
+		topic.Partitions = k.NumPartitions
+	}
+	if topic.ReplicationFactor == 0 {
+		topic.ReplicationFactor = k.ReplicationFactor
+	}
+
+	return topic
+}
+
+func (k *KafkaTopics) Validate() error {
+	if len(k.List) == 0 && len(k.Topics) == 0 {
+		return requiredError("list")
+	}
+
+	var errs []error
+	seen := make(map[string]bool)
+
+	for i, topic := range k.All() {
+		path := k.topicPath(i)
+		if seen[topic.Name] {
+			errs = append(errs, fieldErrorf(path, "топик %q объявлен повторно", topic.Name))
+		}
+		seen[topic.Name] = true
+
+		errs = append(errs, fieldError(path, topic.validate()))
+	}
+
+	return errors.Join(errs...)
+}
+
+// topicPath возвращает путь до i-го топика из All: list[i] или topics[i]
+func (k KafkaTopics) topicPath(i int) string {
+	if i < len(k.List) {
+		return fmt.Sprintf("list[%d]", i)
+	}
+
+	return fmt.Sprintf("topics[%d]", i-len(k.List))
+}
+
+func (topic KafkaTopic) validate() error {
+	var errs []error
+
+	switch {
+	case topic.Name == "." || topic.Name == "..":
+		errs = append(errs, fieldErrorf("name", "имя топика не может быть %q", topic.Name))
+	case !kafkaTopicName.MatchString(topic.Name):
+		errs = append(errs, fieldErrorf("name", "некорректное имя топика %q: допустимы латинские буквы, цифры, '.', '_' и '-', не длиннее 249 символов", topic.Name))
+	}
+
+	if topic.Partitions <= 0 {
+		errs = append(errs, fieldErrorf("partitions", "должно быть больше нуля (или задайте numPartitions)"))
+	}
+
+	if topic.ReplicationFactor <= 0 {
+		errs = append(errs, fieldErrorf("replicationFactor", "должно быть больше нуля (или задайте replicationFactor для всех топиков)"))
+	}
+
+	switch topic.CleanupPolicy {
+	case "", "delete", "compact", "compact,delete", "delete,compact":
+	default:
+		errs = append(errs, fieldErrorf("cleanupPolicy", "некорректное значение %q, допустимо: delete, compact, compact,delete", topic.CleanupPolicy))
+	}
+
+	if topic.Retention < 0 {
+		errs = append(errs, fieldErrorf("retention", "не может быть отрицательным"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkReplicationFactor проверяет, что replicationFactor топиков из KafkaTopics не превышает
+// количество брокеров в Kafka.Brokers. Без секции Kafka или без общих brokers проверка пропускается.
+func checkReplicationFactor(root reflect.Value) error {
+	var brokers []string
+	_ = walk(root, "", func(v reflect.Value, _ string) error {
+		if kafka, ok := v.Interface().(*Kafka); ok && brokers == nil {
+			brokers = kafka.Brokers
+		}
+		return nil
+	})
+	if len(brokers) == 0 {
+		return nil
+	}
+
+	return walk(root, "", func(v reflect.Value, _ string) error {
+		topics, ok := v.Interface().(*KafkaTopics)
+		if !ok {
+			return nil
+		}
+
+		var errs []error
+		for i, topic := range topics.All() {
+			if topic.ReplicationFactor > len(brokers) {
+				errs = append(errs, fieldError(topics.topicPath(i), fieldErrorf("replicationFactor",
+					"%d превышает количество брокеров в kafka.brokers (%d)", topic.ReplicationFactor, len(brokers))))
+			}
+		}
+
+		return errors.Join(errs...)
+	})
+}
+
+// ConfigEntries возвращает параметры топика для создания через admin API:
+// Config, дополненный retention.ms и cleanup.policy
+func (t KafkaTopic) ConfigEntries() map[string]string {
+	entries := make(map[string]string, len(t.Config)+2)
+	for key, value := range t.Config {
+		entries[key] = value
+	}
+
+	if t.Retention > 0 {
+		entries["retention.ms"] = strconv.FormatInt(t.Retention.Duration().Milliseconds(), 10)
+	}
+	if t.CleanupPolicy != "" {
+		entries["cleanup.policy"] = t.CleanupPolicy
+	}
+
+	return entries
+}
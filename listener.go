@@ -0,0 +1,101 @@
+package configo
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"reflect"
+	"strings"
+)
+
+// listener адрес, который будет слушать сервер из секции конфига
+type listener struct {
+	path string
+	host string
+	port Port
+}
+
+// listenerOf возвращает адрес для секций-серверов. Выключенные серверы пропускаются.
+func listenerOf(v any) (host string, port Port, ok bool) {
+	switch s := v.(type) {
+	case *Service:
+		return "", s.Port, true
+	case *Rest:
+		return s.Host, s.Port, true
+	case *GrpcServer:
+		return s.Host, s.Port, true
+	case *Ws:
+		return s.Host, s.Port, s.Enabled
+	}
+
+	return "", 0, false
+}
+
+// checkListeners ищет серверы, которые слушают один порт на пересекающихся хостах.
+// Если bind включен, дополнительно проверяет, что каждый порт можно занять.
+func checkListeners(root reflect.Value, bind bool) error {
+	var listeners []listener
+
+	_ = walk(root, "", func(v reflect.Value, path string) error {
+		if host, port, ok := listenerOf(v.Interface()); ok && port != 0 {
+			listeners = append(listeners, listener{path: path, host: host, port: port})
+		}
+		return nil
+	})
+
+	var errs []error
+
+	for i, l := range listeners {
+		for _, prev := range listeners[:i] {
+			if l.port == prev.port && hostsOverlap(l.host, prev.host) {
+				errs = append(errs, fieldErrorf(joinPath(l.path, "port"), "порт %d уже используется в %s", l.port, displayPath(prev.path)))
+			}
+		}
+	}
+
+	if bind && len(errs) == 0 {
+		for _, l := range listeners {
+			ln, err := net.Listen("tcp", net.JoinHostPort(l.host, l.port.String()))
+			if err != nil {
+				errs = append(errs, fieldError(joinPath(l.path, "port"), err))
+				continue
+			}
+			_ = ln.Close()
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// hostsOverlap сообщает, могут ли серверы на этих хостах конфликтовать по порту
+func hostsOverlap(a, b string) bool {
+	if isWildcardHost(a) || isWildcardHost(b) {
+		return true
+	}
+
+	ipA, errA := netip.ParseAddr(strings.Trim(a, "[]"))
+	ipB, errB := netip.ParseAddr(strings.Trim(b, "[]"))
+	if errA == nil && errB == nil {
+		return ipA.Unmap() == ipB.Unmap()
+	}
+
+	return strings.EqualFold(a, b)
+}
+
+func isWildcardHost(host string) bool {
+	switch strings.Trim(host, "[]") {
+	case "", "0.0.0.0", "::":
+		return true
+	}
+
+	return false
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "корне конфига"
+	}
+
+	return fmt.Sprintf("%q", path)
+}
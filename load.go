@@ -0,0 +1,290 @@
+package configo
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Validator реализуется секциями конфига, которые проверяют себя после загрузки.
+// Validate вызывается для каждой найденной в конфиге секции, включая сам TConfig.
+type Validator interface {
+	Validate() error
+}
+
+// preparer реализуется секциями, которым нужно дозаполнить поля до валидации
+type preparer interface {
+	prepare(lc *loadContext) error
+}
+
+// loadContext сведения о загрузке, доступные секциям при подготовке
+type loadContext struct {
+	dir        string // Каталог файла конфига, относительно него разрешаются пути
+	env        Env    // Окружение из TConfig.Env
+	app        *App   // Первая найденная в конфиге секция App
+	checkPorts bool   // Проверять, что порты серверов свободны
+}
+
+// Option настраивает загрузку конфига в MustLoad
+type Option func(lc *loadContext)
+
+// WithPortCheck включает проверку при загрузке, что порты всех серверов из конфига можно занять
+func WithPortCheck() Option {
+	return func(lc *loadContext) {
+		lc.checkPorts = true
+	}
+}
+
+// FieldError ошибка значения конкретного поля конфига
+type FieldError struct {
+	Path string // Путь до поля по yaml-именам, например "database.host"
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// fieldError привязывает ошибку к полю, дописывая путь к уже вложенным FieldError
+func fieldError(path string, err error) error {
+	if err == nil || path == "" {
+		return err
+	}
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs := joined.Unwrap()
+		wrapped := make([]error, 0, len(errs))
+		for _, e := range errs {
+			wrapped = append(wrapped, fieldError(path, e))
+		}
+		return errors.Join(wrapped...)
+	}
+
+	if fe, ok := err.(*FieldError); ok {
+		return &FieldError{Path: joinPath(path, fe.Path), Err: fe.Err}
+	}
+
+	return &FieldError{Path: path, Err: err}
+}
+
+func fieldErrorf(path, format string, args ...any) error {
+	return &FieldError{Path: path, Err: fmt.Errorf(format, args...)}
+}
+
+func requiredError(path string) error {
+	return fieldErrorf(path, "обязательное поле не заполнено")
+}
+
+func joinPath(parent, child string) string {
+	switch {
+	case parent == "":
+		return child
+	case child == "":
+		return parent
+	case strings.HasPrefix(child, "["):
+		return parent + child
+	default:
+		return parent + "." + child
+	}
+}
+
+// scalarError оформляет ошибку значения как *yaml.TypeError с номером строки:
+// такие ошибки yaml.v3 собирает все, не прерывая разбор на первой
+func scalarError(node *yaml.Node, err error) *yaml.TypeError {
+	return &yaml.TypeError{Errors: []string{fmt.Sprintf("строка %d: %v", node.Line, err)}}
+}
+
+// unmarshalScalar разбирает yaml-скаляр через UnmarshalText, ошибка содержит номер строки
+func unmarshalScalar(node *yaml.Node, u encoding.TextUnmarshaler) error {
+	if node.Kind != yaml.ScalarNode {
+		return scalarError(node, errors.New("ожидается скалярное значение"))
+	}
+	if err := u.UnmarshalText([]byte(node.Value)); err != nil {
+		return scalarError(node, err)
+	}
+
+	return nil
+}
+
+// mergeField переносит значение, разобранное из url, в поле секции.
+// Если поле уже заполнено другим значением, возвращается ошибка конфликта.
+func mergeField[T comparable](dst *T, src T, path string) error {
+	var zero T
+	if src == zero {
+		return nil
+	}
+
+	if *dst == zero {
+		*dst = src
+		return nil
+	}
+
+	if *dst != src {
+		return fieldErrorf(path, "значение противоречит указанному в url")
+	}
+
+	return nil
+}
+
+// loadURLEnv читает строку подключения секции из переменной <ПУТЬ>_URL:
+// DATABASE_URL для секции database, ANALYTICS_DB_URL для analyticsDb.
+// Имя зависит от пути, поэтому несколько секций одного типа настраиваются раздельно.
+func loadURLEnv(dst *string, prefix string) {
+	if prefix == "" {
+		return
+	}
+
+	if value, ok := os.LookupEnv(prefix + "_URL"); ok {
+		*dst = value
+	}
+}
+
+// finalize дозаполняет и проверяет загруженный конфиг
+func finalize(cfg any, lc *loadContext) error {
+	root := reflect.ValueOf(cfg).Elem()
+
+	_ = walk(root, "", func(v reflect.Value, _ string) error {
+		if app, ok := v.Interface().(*App); ok && lc.app == nil {
+			lc.app = app
+		}
+		return nil
+	})
+
+	err := walkHooks(root, "", func(v reflect.Value, path string, embeddedIn reflect.Type) error {
+		if l, ok := v.Interface().(envLoader); ok && !implements(embeddedIn, envLoaderType) {
+			if err := l.loadEnv(envPrefix(path)); err != nil {
+				return err
+			}
+		}
+		if p, ok := v.Interface().(preparer); ok && !implements(embeddedIn, preparerType) {
+			return p.prepare(lc)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = walkHooks(root, "", func(v reflect.Value, _ string, embeddedIn reflect.Type) error {
+		if val, ok := v.Interface().(Validator); ok && !implements(embeddedIn, validatorType) {
+			return val.Validate()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(
+		checkListeners(root, lc.checkPorts),
+		checkRetryTopics(root),
+		checkReplicationFactor(root),
+	)
+}
+
+// walk обходит конфиг в глубину и вызывает fn для указателя на каждое значение и пути до него.
+// Элементы map копируются, обрабатываются и записываются обратно.
+func walk(v reflect.Value, path string, fn func(v reflect.Value, path string) error) error {
+	return walkHooks(v, path, func(v reflect.Value, path string, _ reflect.Type) error {
+		return fn(v, path)
+	})
+}
+
+// walkHooks обходит конфиг как walk, дополнительно передавая в fn тип указателя на структуру,
+// в которую значение встроено анонимным полем, или nil
+func walkHooks(v reflect.Value, path string, fn func(v reflect.Value, path string, embeddedIn reflect.Type) error) error {
+	return walkValue(v, path, nil, fn)
+}
+
+func walkValue(v reflect.Value, path string, embeddedIn reflect.Type, fn func(v reflect.Value, path string, embeddedIn reflect.Type) error) error {
+	var errs []error
+
+	if v.CanAddr() {
+		if err := fn(v.Addr(), path, embeddedIn); err != nil {
+			errs = append(errs, fieldError(path, err))
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			errs = append(errs, walkValue(v.Elem(), path, embeddedIn, fn))
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			f := t.Field(i)
+			name, ok := fieldName(f)
+			if !ok {
+				continue
+			}
+			var parent reflect.Type
+			if f.Anonymous {
+				parent = reflect.PointerTo(t)
+			}
+			errs = append(errs, walkValue(v.Field(i), joinPath(path, name), parent, fn))
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			errs = append(errs, walkValue(v.Index(i), joinPath(path, fmt.Sprintf("[%d]", i)), nil, fn))
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		for _, key := range keys {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(key))
+			errs = append(errs, walkValue(elem, joinPath(path, fmt.Sprint(key)), nil, fn))
+			v.SetMapIndex(key, elem)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+var (
+	validatorType = reflect.TypeFor[Validator]()
+	preparerType  = reflect.TypeFor[preparer]()
+	envLoaderType = reflect.TypeFor[envLoader]()
+)
+
+// implements сообщает, что структура, в которую встроено значение, сама реализует iface.
+// Тогда метод входит в ее набор методов, продвинутым из поля или переопределенным, и уже вызван у нее:
+// повторный вызов у встроенного поля выполнил бы хук дважды
+func implements(embeddedIn, iface reflect.Type) bool {
+	return embeddedIn != nil && embeddedIn.Implements(iface)
+}
+
+// fieldName возвращает yaml-имя поля для путей в ошибках
+func fieldName(f reflect.StructField) (string, bool) {
+	if !f.IsExported() {
+		return "", false
+	}
+
+	tag := f.Tag.Get("yaml")
+	if tag == "-" {
+		return "", false
+	}
+
+	name, opts, _ := strings.Cut(tag, ",")
+	if f.Anonymous || strings.Contains(opts, "inline") {
+		return "", true
+	}
+	if name == "" {
+		name = f.Name
+	}
+
+	return name, true
+}
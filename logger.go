@@ -0,0 +1,36 @@
+package configo
+
+import "errors"
+
+func (l *Logger) Validate() error {
+	var errs []error
+
+	if l.MaxSize.ByteSize() < MiB {
+		errs = append(errs, fieldErrorf("maxSize", "%s меньше 1MiB", l.MaxSize))
+	}
+
+	if l.MaxAge < 0 {
+		errs = append(errs, fieldErrorf("maxAge", "не может быть отрицательным"))
+	}
+	if l.RotationTime <= 0 {
+		errs = append(errs, fieldErrorf("rotationTime", "должно быть больше нуля"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// MaxSizeMB возвращает MaxSize в мегабайтах, как ожидает lumberjack, с округлением вверх
+func (l Logger) MaxSizeMB() int {
+	size := l.MaxSize.ByteSize()
+	mb := size / MiB
+	if size%MiB != 0 {
+		mb++
+	}
+
+	return int(mb)
+}
+
+// MaxAgeDays возвращает MaxAge в полных днях, как ожидает lumberjack
+func (l Logger) MaxAgeDays() int {
+	return int(l.MaxAge.Duration() / Day)
+}
@@ -0,0 +1,54 @@
+package configo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ilyakaznacheev/cleanenv"
+)
+
+func TestLoggerMaxSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		wantMB  int
+		wantErr string
+	}{
+		{"по умолчанию", "", 10, ""},
+		{"число мегабайт", "100", 100, ""},
+		{"с единицей", "100MiB", 100, ""},
+		{"округление вверх", "1500KiB", 2, ""},
+		{"десятичные мегабайты", "10MB", 10, ""},
+		{"меньше мегабайта", "512KiB", 0, "maxSize"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.env != "" {
+				t.Setenv("LOGGER_MAX_SIZE", tt.env)
+			}
+
+			var cfg struct {
+				MaxSize ByteSizeMB `env:"LOGGER_MAX_SIZE" env-default:"10MiB"`
+			}
+			if err := cleanenv.ReadEnv(&cfg); err != nil {
+				t.Fatalf("ReadEnv: %v", err)
+			}
+
+			l := Logger{MaxSize: cfg.MaxSize, RotationTime: Duration(Day)}
+			err := l.Validate()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := l.MaxSizeMB(); got != tt.wantMB {
+				t.Errorf("MaxSizeMB() = %d, want %d", got, tt.wantMB)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package configo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Migration пара файлов миграции с одной версией.
+// Имена файлов в формате golang-migrate: {version}_{name}.up.sql и {version}_{name}.down.sql
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string // Путь к up-файлу
+	Down    string // Путь к down-файлу, пусто если его нет
+}
+
+// Migrations возвращает миграции из MigrationPath, упорядоченные по версии.
+// Ошибка возвращается, если каталог пуст, версии дублируются, у down-файла нет up-файла
+// или при последовательной нумерации (с 0 или 1) пропущена версия.
+// Версии-метки времени на пропуски не проверяются.
+func (d Database) Migrations() ([]Migration, error) {
+	entries, err := os.ReadDir(d.MigrationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint64]*Migration)
+	var errs []error
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		version, name, direction, ok := parseMigrationName(entry.Name())
+		if !ok {
+			continue
+		}
+
+		m, found := byVersion[version]
+		if !found {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+
+		path := filepath.Join(d.MigrationPath, entry.Name())
+		target := &m.Up
+		if direction == "down" {
+			target = &m.Down
+		}
+
+		if *target != "" {
+			errs = append(errs, fmt.Errorf("дублируется %s-миграция версии %d: %s и %s", direction, version, filepath.Base(*target), entry.Name()))
+			continue
+		}
+		*target = path
+	}
+
+	if len(byVersion) == 0 {
+		return nil, fmt.Errorf("в каталоге %s нет файлов миграций", d.MigrationPath)
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			errs = append(errs, fmt.Errorf("для версии %d нет up-миграции", m.Version))
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	if migrations[0].Version <= 1 {
+		for i := 1; i < len(migrations); i++ {
+			if prev := migrations[i-1].Version; migrations[i].Version != prev+1 {
+				errs = append(errs, fmt.Errorf("пропущены версии между %d и %d", prev, migrations[i].Version))
+			}
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return migrations, nil
+}
+
+// parseMigrationName разбирает имя вида 0001_create_users.up.sql
+func parseMigrationName(file string) (version uint64, name, direction string, ok bool) {
+	base := strings.TrimSuffix(file, filepath.Ext(file))
+
+	switch {
+	case strings.HasSuffix(base, ".up"):
+		direction = "up"
+	case strings.HasSuffix(base, ".down"):
+		direction = "down"
+	default:
+		return 0, "", "", false
+	}
+	base = strings.TrimSuffix(base, "."+direction)
+
+	rawVersion, name, _ := strings.Cut(base, "_")
+	version, err := strconv.ParseUint(rawVersion, 10, 64)
+	if err != nil {
+		return 0, "", "", false
+	}
+
+	return version, name, direction, true
+}
@@ -0,0 +1,319 @@
+package configo
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NamedDefault имя записи, значения которой наследуют остальные записи Named
+const NamedDefault = "default"
+
+// Named набор именованных экземпляров одной секции, например
+//
+//	databases:
+//	  default: {host: db, port: 5432, user: app}
+//	  main: {name: main}
+//	  analytics: {name: analytics, host: olap}
+//
+// Записи наследуют значения из default, сама default экземпляром не считается.
+// Переменные окружения читаются по пути до поля: DATABASES_MAIN_HOST, DATABASES_MAIN_MIGRATION_PATH.
+// Значения env-default и проверка env-required применяются к каждой записи.
+type Named[T any] map[string]T
+
+// envLoader реализуется секциями, которые читают переменные окружения сами,
+// так как cleanenv не обрабатывает значения map
+type envLoader interface {
+	loadEnv(prefix string) error
+}
+
+func (n *Named[T]) UnmarshalYAML(node *yaml.Node) error {
+	var raw map[string]yaml.Node
+	if err := node.Decode(&raw); err != nil {
+		return err
+	}
+
+	base, hasBase := raw[NamedDefault]
+	result := make(Named[T], len(raw))
+	terr := &yaml.TypeError{}
+
+	// default декодируется для каждой записи заново, чтобы записи не делили map и срезы.
+	// Ошибки default проверяются и без записей, иначе одиночный default не валидировался бы
+	decodeBase := func(value *T) error {
+		if !hasBase {
+			return nil
+		}
+		if err := base.Decode(value); err != nil {
+			return prefixYAMLError(NamedDefault, err)
+		}
+		return nil
+	}
+
+	var probe T
+	if err := decodeBase(&probe); err != nil {
+		return err
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(raw)) {
+		if name == NamedDefault {
+			continue
+		}
+
+		var value T
+		if err := decodeBase(&value); err != nil {
+			return err
+		}
+		entry := raw[name]
+		if err := entry.Decode(&value); err != nil {
+			err = prefixYAMLError(name, err)
+			if e, ok := err.(*yaml.TypeError); ok {
+				terr.Errors = append(terr.Errors, e.Errors...)
+				continue
+			}
+			return err
+		}
+
+		result[name] = value
+	}
+	if len(terr.Errors) > 0 {
+		return terr
+	}
+
+	*n = result
+	return nil
+}
+
+// prefixYAMLError дописывает имя экземпляра к ошибкам разбора, сохраняя *yaml.TypeError
+func prefixYAMLError(name string, err error) error {
+	var terr *yaml.TypeError
+	if !errors.As(err, &terr) {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	errs := make([]string, 0, len(terr.Errors))
+	for _, e := range terr.Errors {
+		errs = append(errs, name+": "+e)
+	}
+
+	return &yaml.TypeError{Errors: errs}
+}
+
+// Get возвращает экземпляр по имени
+func (n Named[T]) Get(name string) (T, error) {
+	value, ok := n[name]
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("неизвестный экземпляр %q, доступны: %v", name, n.Names())
+	}
+
+	return value, nil
+}
+
+// Names возвращает имена экземпляров в алфавитном порядке
+func (n Named[T]) Names() []string {
+	return slices.Sorted(maps.Keys(n))
+}
+
+func (n *Named[T]) loadEnv(prefix string) error {
+	var errs []error
+
+	for _, name := range n.Names() {
+		value := (*n)[name]
+		if err := loadStructEnv(reflect.ValueOf(&value).Elem(), joinEnv(prefix, envName(name))); err != nil {
+			errs = append(errs, fieldError(name, err))
+		}
+		(*n)[name] = value
+	}
+
+	return errors.Join(errs...)
+}
+
+// loadStructEnv заполняет поля структуры из переменных окружения с именами по yaml-тегам,
+// затем применяет env-default и проверяет env-required
+func loadStructEnv(v reflect.Value, prefix string) error {
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var errs []error
+	t := v.Type()
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, ok := fieldName(f)
+		if !ok {
+			continue
+		}
+
+		field := v.Field(i)
+		env := joinEnv(prefix, envName(name))
+
+		if field.Kind() == reflect.Struct && !isTextUnmarshaler(field) {
+			errs = append(errs, fieldError(name, loadStructEnv(field, env)))
+			continue
+		}
+
+		separator := f.Tag.Get("env-separator")
+		if separator == "" {
+			separator = ","
+		}
+
+		raw, found := os.LookupEnv(env)
+		if !found && field.IsZero() {
+			raw, found = f.Tag.Lookup("env-default")
+		}
+
+		if !found {
+			if _, required := f.Tag.Lookup("env-required"); required && field.IsZero() {
+				errs = append(errs, requiredError(name))
+			}
+			continue
+		}
+
+		if err := setFromString(field, raw, separator); err != nil {
+			errs = append(errs, fieldErrorf(name, "%s: %w", env, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// setFromString разбирает строковое значение переменной окружения в поле
+func setFromString(field reflect.Value, raw, separator string) error {
+	if u, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(raw))
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if field.Type() == reflect.TypeOf(time.Duration(0)) {
+			d, err := time.ParseDuration(raw)
+			if err != nil {
+				return err
+			}
+			field.SetInt(int64(d))
+			return nil
+		}
+		n, err := strconv.ParseInt(raw, 0, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 0, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(n)
+	case reflect.Slice:
+		if strings.TrimSpace(raw) == "" {
+			field.Set(reflect.MakeSlice(field.Type(), 0, 0))
+			return nil
+		}
+		parts := strings.Split(raw, separator)
+		slice := reflect.MakeSlice(field.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setFromString(slice.Index(i), strings.TrimSpace(part), separator); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+	case reflect.Map:
+		m := reflect.MakeMap(field.Type())
+		for _, pair := range strings.Split(raw, separator) {
+			if strings.TrimSpace(pair) == "" {
+				continue
+			}
+			k, val, ok := strings.Cut(pair, ":")
+			if !ok {
+				return fmt.Errorf("некорректный элемент %q, ожидается key:value", pair)
+			}
+			key := reflect.New(field.Type().Key()).Elem()
+			elem := reflect.New(field.Type().Elem()).Elem()
+			if err := setFromString(key, strings.TrimSpace(k), separator); err != nil {
+				return err
+			}
+			if err := setFromString(elem, strings.TrimSpace(val), separator); err != nil {
+				return err
+			}
+			m.SetMapIndex(key, elem)
+		}
+		field.Set(m)
+	default:
+		return fmt.Errorf("тип %s не поддерживается", field.Type())
+	}
+
+	return nil
+}
+
+func isTextUnmarshaler(v reflect.Value) bool {
+	_, ok := v.Addr().Interface().(encoding.TextUnmarshaler)
+	return ok
+}
+
+// envName переводит имя в формат переменной окружения: migrationPath -> MIGRATION_PATH
+func envName(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			b.WriteByte('_')
+			continue
+		}
+
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || unicode.IsUpper(prev) && nextLower {
+				b.WriteByte('_')
+			}
+		}
+
+		b.WriteRune(unicode.ToUpper(r))
+	}
+
+	return b.String()
+}
+
+// envPrefix строит префикс переменных окружения по пути в конфиге: kafka.producers -> KAFKA_PRODUCERS
+func envPrefix(path string) string {
+	var parts []string
+	for _, part := range strings.FieldsFunc(path, func(r rune) bool { return r == '.' || r == '[' || r == ']' }) {
+		parts = append(parts, envName(part))
+	}
+
+	return strings.Join(parts, "_")
+}
+
+func joinEnv(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "_" + name
+}
@@ -0,0 +1,132 @@
+package configo
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type namedTestEntry struct {
+	Host     string        `yaml:"host"`
+	Port     Port          `yaml:"port" env-default:"5432"`
+	Name     string        `yaml:"name" env-required:"true"`
+	Timeout  time.Duration `yaml:"timeout" env-default:"5s"`
+	Tags     []string      `yaml:"tags" env-separator:";"`
+	MaxSize  ByteSize      `yaml:"maxSize"`
+	Settings struct {
+		Debug bool `yaml:"debug"`
+	} `yaml:"settings"`
+}
+
+func TestNamedUnmarshalYAML(t *testing.T) {
+	var n Named[namedTestEntry]
+	err := yaml.Unmarshal([]byte(`
+default: {host: db, port: 6432, tags: [a]}
+main: {name: main}
+analytics: {name: olap, host: olap-db}
+`), &n)
+	if err != nil {
+		t.Fatalf("yaml: %v", err)
+	}
+
+	if got := n.Names(); strings.Join(got, ",") != "analytics,main" {
+		t.Errorf("Names() = %v", got)
+	}
+
+	main, _ := n.Get("main")
+	if main.Host != "db" || main.Port != 6432 || main.Name != "main" || len(main.Tags) != 1 {
+		t.Errorf("main = %+v, значения default не унаследованы", main)
+	}
+	analytics, _ := n.Get("analytics")
+	if analytics.Host != "olap-db" || analytics.Port != 6432 {
+		t.Errorf("analytics = %+v, значения записи должны перекрывать default", analytics)
+	}
+	if _, err := n.Get("default"); err == nil {
+		t.Error("default не должна быть экземпляром")
+	}
+}
+
+func TestNamedUnmarshalYAMLErrors(t *testing.T) {
+	var n Named[namedTestEntry]
+	err := yaml.Unmarshal([]byte("a:\n  port: abc\nb:\n  port: 70000\n"), &n)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, want := range []string{`a: строка 2: некорректный порт "abc"`, `b: строка 4: некорректный порт "70000"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error = %v, want containing %q", err, want)
+		}
+	}
+}
+
+func TestNamedLoadEnv(t *testing.T) {
+	n := Named[namedTestEntry]{
+		"main":      {Host: "db", Name: "main"},
+		"analytics": {Host: "olap"},
+	}
+
+	t.Setenv("DATABASES_MAIN_HOST", "db-from-env")
+	t.Setenv("DATABASES_MAIN_TAGS", "x; y")
+	t.Setenv("DATABASES_MAIN_MAX_SIZE", "1MiB")
+	t.Setenv("DATABASES_MAIN_SETTINGS_DEBUG", "true")
+	t.Setenv("DATABASES_ANALYTICS_NAME", "olap")
+	t.Setenv("DATABASES_ANALYTICS_TIMEOUT", "1m")
+
+	if err := n.loadEnv("DATABASES"); err != nil {
+		t.Fatalf("loadEnv: %v", err)
+	}
+
+	main := n["main"]
+	if main.Host != "db-from-env" || main.Port != 5432 || main.Timeout != 5*time.Second ||
+		strings.Join(main.Tags, ",") != "x,y" || main.MaxSize != MiB || !main.Settings.Debug {
+		t.Errorf("main = %+v", main)
+	}
+	analytics := n["analytics"]
+	if analytics.Name != "olap" || analytics.Timeout != time.Minute || analytics.Host != "olap" {
+		t.Errorf("analytics = %+v", analytics)
+	}
+}
+
+func TestNamedLoadEnvErrors(t *testing.T) {
+	n := Named[namedTestEntry]{"main": {}}
+	t.Setenv("DATABASES_MAIN_PORT", "70000")
+
+	err := n.loadEnv("DATABASES")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, want := range []string{"main.port: DATABASES_MAIN_PORT:", "main.name: обязательное поле не заполнено"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error = %v, want containing %q", err, want)
+		}
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	tests := map[string]string{
+		"host":          "HOST",
+		"migrationPath": "MIGRATION_PATH",
+		"baseURL":       "BASE_URL",
+		"sslRootCert":   "SSL_ROOT_CERT",
+		"main-db":       "MAIN_DB",
+		"db2":           "DB2",
+	}
+
+	for name, want := range tests {
+		if got := envName(name); got != want {
+			t.Errorf("envName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestNamedUnmarshalYAMLDefaultError(t *testing.T) {
+	for _, doc := range []string{"default:\n  port: abc\nmain: {name: main}\n", "default:\n  port: abc\n"} {
+		var n Named[namedTestEntry]
+		err := yaml.Unmarshal([]byte(doc), &n)
+		if err == nil || !strings.Contains(err.Error(), `default: строка 2: некорректный порт "abc"`) {
+			t.Errorf("error = %v, want default entry error", err)
+		}
+	}
+}
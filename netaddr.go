@@ -0,0 +1,242 @@
+package configo
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Port сетевой порт от 0 до 65535. В yaml и env принимает число или строку: 8080, "8080"
+type Port uint16
+
+func (p *Port) UnmarshalText(text []byte) error {
+	port, err := ParsePort(string(text))
+	if err != nil {
+		return err
+	}
+
+	*p = port
+	return nil
+}
+
+func (p *Port) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalScalar(node, p)
+}
+
+func (p *Port) UnmarshalJSON(data []byte) error {
+	return p.UnmarshalText([]byte(strings.Trim(string(data), `"`)))
+}
+
+func (p Port) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p Port) String() string {
+	return strconv.Itoa(int(p))
+}
+
+// ParsePort разбирает порт из строки с проверкой диапазона
+func ParsePort(value string) (Port, error) {
+	port, err := strconv.ParseUint(strings.TrimSpace(value), 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("некорректный порт %q: ожидается число от 0 до 65535", value)
+	}
+
+	return Port(port), nil
+}
+
+// HostPort адрес вида host:port, IPv6 указывается в квадратных скобках: [::1]:8080
+type HostPort string
+
+func NewHostPort(host string, port Port) HostPort {
+	return HostPort(net.JoinHostPort(host, port.String()))
+}
+
+// ParseHostPort разбирает и нормализует адрес вида host:port
+func ParseHostPort(value string) (HostPort, error) {
+	host, rawPort, err := net.SplitHostPort(strings.TrimSpace(value))
+	if err != nil {
+		return "", fmt.Errorf("некорректный адрес %q: ожидается host:port", value)
+	}
+
+	port, err := ParsePort(rawPort)
+	if err != nil {
+		return "", err
+	}
+
+	return NewHostPort(host, port), nil
+}
+
+func (h *HostPort) UnmarshalText(text []byte) error {
+	hp, err := ParseHostPort(string(text))
+	if err != nil {
+		return err
+	}
+
+	*h = hp
+	return nil
+}
+
+func (h *HostPort) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalScalar(node, h)
+}
+
+func (h HostPort) Host() string {
+	host, _, _ := net.SplitHostPort(string(h))
+	return host
+}
+
+func (h HostPort) Port() Port {
+	_, rawPort, _ := net.SplitHostPort(string(h))
+	port, _ := ParsePort(rawPort)
+	return port
+}
+
+func (h HostPort) String() string {
+	return string(h)
+}
+
+// Addr возвращает адрес для прослушивания на всех интерфейсах
+func (s Service) Addr() string {
+	return net.JoinHostPort("", s.Port.String())
+}
+
+func (r Rest) Addr() string {
+	return net.JoinHostPort(r.Host, r.Port.String())
+}
+
+func (s GrpcServer) Addr() string {
+	return net.JoinHostPort(s.Host, s.Port.String())
+}
+
+func (c GrpcClient) Addr() string {
+	return net.JoinHostPort(c.Host, c.Port.String())
+}
+
+func (w Ws) Addr() string {
+	return net.JoinHostPort(w.Host, w.Port.String())
+}
+
+func (d Database) Addr() string {
+	return net.JoinHostPort(d.Host, d.Port.String())
+}
+
+func (r DatabaseReplica) Addr() string {
+	return net.JoinHostPort(r.Host, r.Port.String())
+}
+
+func hostPortStrings(addrs []HostPort) []string {
+	result := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		result = append(result, addr.String())
+	}
+
+	return result
+}
+
+// CIDRList список подсетей. Отдельный IP принимается как подсеть из одного адреса.
+// В yaml задается списком или строкой через запятую, в env - строкой через запятую
+type CIDRList []netip.Prefix
+
+func (l *CIDRList) UnmarshalText(text []byte) error {
+	var (
+		list CIDRList
+		errs []error
+	)
+
+	for _, value := range strings.Split(string(text), ",") {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		prefix, err := ParseCIDR(value)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		list = append(list, prefix)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	*l = list
+	return nil
+}
+
+func (l *CIDRList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.SequenceNode {
+		return unmarshalScalar(node, l)
+	}
+
+	list := make(CIDRList, 0, len(node.Content))
+	terr := &yaml.TypeError{}
+
+	for _, item := range node.Content {
+		if item.Kind != yaml.ScalarNode {
+			terr.Errors = append(terr.Errors, scalarError(item, errors.New("ожидается CIDR или IP")).Errors...)
+			continue
+		}
+		prefix, err := ParseCIDR(item.Value)
+		if err != nil {
+			terr.Errors = append(terr.Errors, scalarError(item, err).Errors...)
+			continue
+		}
+		list = append(list, prefix)
+	}
+	if len(terr.Errors) > 0 {
+		return terr
+	}
+
+	*l = list
+	return nil
+}
+
+func (l CIDRList) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+func (l CIDRList) String() string {
+	values := make([]string, 0, len(l))
+	for _, prefix := range l {
+		values = append(values, prefix.String())
+	}
+
+	return strings.Join(values, ",")
+}
+
+// Contains проверяет, входит ли адрес в одну из подсетей
+func (l CIDRList) Contains(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	for _, prefix := range l {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseCIDR разбирает подсеть вида 10.0.0.0/8 или отдельный IP
+func ParseCIDR(value string) (netip.Prefix, error) {
+	value = strings.TrimSpace(value)
+
+	if prefix, err := netip.ParsePrefix(value); err == nil {
+		if prefix.Addr().Is4In6() {
+			return netip.Prefix{}, fmt.Errorf("некорректная подсеть %q: IPv4 в IPv6-записи не поддерживается", value)
+		}
+		return prefix.Masked(), nil
+	}
+
+	ip, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("некорректная подсеть %q: ожидается CIDR или IP", value)
+	}
+	ip = ip.Unmap()
+
+	return netip.PrefixFrom(ip, ip.BitLen()), nil
+}
@@ -0,0 +1,133 @@
+package configo
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	RedisStandalone = "standalone"
+	RedisSentinel   = "sentinel"
+	RedisCluster    = "cluster"
+)
+
+// RedisDefaultPort порт Redis, если он не указан ни в url, ни в port
+const RedisDefaultPort Port = 6379
+
+func (r *Redis) loadEnv(prefix string) error {
+	loadURLEnv(&r.URL, prefix)
+	return nil
+}
+
+func (r *Redis) prepare(_ *loadContext) error {
+	if err := r.parseURL(); err != nil {
+		return err
+	}
+
+	if r.Port == 0 && (r.Mode == "" || r.Mode == RedisStandalone) {
+		r.Port = RedisDefaultPort
+	}
+
+	return nil
+}
+
+func (r *Redis) parseURL() error {
+	if r.URL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(r.URL)
+	if err != nil {
+		return fieldError("url", err)
+	}
+
+	if u.Scheme != "redis" && u.Scheme != "rediss" {
+		return fieldErrorf("url", "неподдерживаемая схема %q, ожидается redis или rediss", u.Scheme)
+	}
+
+	port, err := urlPort(u)
+	if err != nil {
+		return fieldError("url", err)
+	}
+
+	var db int
+	if path := strings.TrimPrefix(u.Path, "/"); path != "" {
+		if db, err = strconv.Atoi(path); err != nil {
+			return fieldErrorf("url", "некорректный номер базы %q", path)
+		}
+	}
+
+	password, _ := u.User.Password()
+
+	if u.Scheme == "rediss" {
+		r.TLS.Enabled = true
+	}
+
+	return errors.Join(
+		mergeField(&r.Host, u.Hostname(), "host"),
+		mergeField(&r.Port, port, "port"),
+		mergeField(&r.Db, db, "db"),
+		mergeField(&r.Username, u.User.Username(), "username"),
+		mergeField(&r.Password, Secret(password), "password"),
+	)
+}
+
+func (r *Redis) Validate() error {
+	var errs []error
+
+	switch r.Mode {
+	case "", RedisStandalone:
+		if r.Host == "" {
+			errs = append(errs, requiredError("host"))
+		}
+		if r.Port == 0 {
+			errs = append(errs, requiredError("port"))
+		}
+	case RedisSentinel:
+		if r.MasterName == "" {
+			errs = append(errs, requiredError("masterName"))
+		}
+		if len(r.SentinelAddrs) == 0 {
+			errs = append(errs, requiredError("sentinelAddrs"))
+		}
+	case RedisCluster:
+		if len(r.ClusterAddrs) == 0 {
+			errs = append(errs, requiredError("clusterAddrs"))
+		}
+		if r.Db != 0 {
+			errs = append(errs, fieldErrorf("db", "в режиме cluster доступна только база 0"))
+		}
+	default:
+		errs = append(errs, fieldErrorf("mode", "некорректное значение %q, допустимо: standalone, sentinel, cluster", r.Mode))
+	}
+
+	if r.PoolSize < 0 {
+		errs = append(errs, fieldErrorf("poolSize", "не может быть отрицательным"))
+	}
+	if r.MinIdleConns < 0 || r.PoolSize > 0 && r.MinIdleConns > r.PoolSize {
+		errs = append(errs, fieldErrorf("minIdleConns", "должно быть от 0 до poolSize (%d)", r.PoolSize))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Addr возвращает адрес сервера в формате host:port для режима standalone
+func (r Redis) Addr() string {
+	return net.JoinHostPort(r.Host, r.Port.String())
+}
+
+// Addrs возвращает адреса для подключения в зависимости от режима:
+// сервер для standalone, sentinel для sentinel и узлы кластера для cluster
+func (r Redis) Addrs() []string {
+	switch r.Mode {
+	case RedisSentinel:
+		return hostPortStrings(r.SentinelAddrs)
+	case RedisCluster:
+		return hostPortStrings(r.ClusterAddrs)
+	default:
+		return []string{r.Addr()}
+	}
+}
@@ -0,0 +1,146 @@
+package configo
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+func (r *Rest) Validate() error {
+	var errs []error
+
+	if r.BasePath != "" && !strings.HasPrefix(r.BasePath, "/") {
+		errs = append(errs, fieldErrorf("basePath", "должен начинаться с /"))
+	}
+	if r.MaxRequestBodySize < 0 {
+		errs = append(errs, fieldErrorf("maxRequestBodySize", "не может быть отрицательным"))
+	}
+	if r.HandlerTimeout > 0 && r.WriteTimeout > 0 && r.HandlerTimeout >= r.WriteTimeout {
+		errs = append(errs, fieldErrorf("handlerTimeout", "%s должно быть меньше writeTimeout (%s), иначе соединение закроется раньше", r.HandlerTimeout, r.WriteTimeout))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (t *RestTLS) Validate() error {
+	if !t.Enabled || t.AutoCert {
+		return nil
+	}
+
+	var errs []error
+
+	if t.CertFile == "" {
+		errs = append(errs, requiredError("certFile"))
+	}
+	if t.KeyFile == "" {
+		errs = append(errs, requiredError("keyFile"))
+	}
+
+	errs = append(errs, checkFile("certFile", t.CertFile), checkFile("keyFile", t.KeyFile))
+
+	return errors.Join(errs...)
+}
+
+// NewServer собирает *http.Server: адрес, таймауты, ограничение размера тела запроса,
+// TLS и монтирование handler под BasePath (префикс отрезается перед вызовом handler).
+// HandlerTimeout включается явно и применяется через http.TimeoutHandler: по истечении клиент получает 503.
+// Такой handler не поддерживает http.Flusher и http.Hijacker, поэтому для SSE и websocket его оставляют 0.
+// AutoCert не поддерживается, для него нужен golang.org/x/crypto/acme/autocert.
+func (r Rest) NewServer(handler http.Handler) (*http.Server, error) {
+	if prefix := strings.TrimSuffix(r.BasePath, "/"); prefix != "" {
+		handler = stripBasePath(prefix, handler)
+	}
+
+	if r.MaxRequestBodySize > 0 {
+		handler = http.MaxBytesHandler(handler, r.MaxRequestBodySize.Int64())
+	}
+
+	if r.HandlerTimeout > 0 {
+		handler = http.TimeoutHandler(handler, r.HandlerTimeout, http.StatusText(http.StatusServiceUnavailable))
+	}
+
+	srv := &http.Server{
+		Addr:         r.Addr(),
+		Handler:      handler,
+		ReadTimeout:  r.ReadTimeout,
+		WriteTimeout: r.WriteTimeout,
+		IdleTimeout:  r.IdleTimeout,
+	}
+
+	if r.TLS.Enabled {
+		if r.TLS.AutoCert {
+			return nil, errors.New("tls.autoCert не поддерживается NewServer, настройте autocert.Manager в TLSConfig вручную")
+		}
+
+		cert, err := tls.LoadX509KeyPair(r.TLS.CertFile, r.TLS.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("загрузка сертификата сервера: %w", err)
+		}
+
+		srv.TLSConfig = &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{cert},
+		}
+	}
+
+	return srv, nil
+}
+
+// Run запускает сервер из NewServer и блокируется до отмены ctx,
+// после чего корректно завершает его в пределах ShutdownTimeout (0 - ждет завершения всех запросов)
+func (r Rest) Run(ctx context.Context, handler http.Handler) error {
+	srv, err := r.NewServer(handler)
+	if err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		return err
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if srv.TLSConfig != nil {
+			serveErr <- srv.ServeTLS(ln, "", "")
+		} else {
+			serveErr <- srv.Serve(ln)
+		}
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithCancel(context.Background())
+	if r.ShutdownTimeout > 0 {
+		shutdownCtx, cancel = context.WithTimeout(shutdownCtx, r.ShutdownTimeout)
+	}
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("завершение сервера %s: %w", srv.Addr, err)
+	}
+
+	return nil
+}
+
+// stripBasePath пропускает к handler только запросы под prefix, отрезая его от пути
+func stripBasePath(prefix string, handler http.Handler) http.Handler {
+	strip := http.StripPrefix(prefix, handler)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != prefix && !strings.HasPrefix(req.URL.Path, prefix+"/") {
+			http.NotFound(w, req)
+			return
+		}
+
+		strip.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,85 @@
+package configo
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// ClientIP определяет IP клиента с учетом TrustedProxies. Заголовки учитываются,
+// только если соединение пришло от доверенного прокси: X-Forwarded-For или Forwarded
+// просматриваются справа налево до первого недоверенного адреса, иначе берется X-Real-IP.
+// Если адрес определить не удалось, возвращается невалидный netip.Addr.
+func (r Rest) ClientIP(req *http.Request) netip.Addr {
+	ip := parseHopIP(req.RemoteAddr)
+	if !ip.IsValid() || !r.TrustedProxies.Contains(ip) {
+		return ip
+	}
+
+	hops := forwardedFor(req.Header)
+	if len(hops) > 0 {
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := parseHopIP(hops[i])
+			if !hop.IsValid() {
+				// Адрес скрыт или поврежден, дальше по цепочке доверять нельзя
+				break
+			}
+			ip = hop
+			if !r.TrustedProxies.Contains(ip) {
+				break
+			}
+		}
+
+		return ip
+	}
+
+	if realIP := parseHopIP(req.Header.Get("X-Real-IP")); realIP.IsValid() {
+		return realIP
+	}
+
+	return ip
+}
+
+// forwardedFor возвращает цепочку адресов из X-Forwarded-For, а если его нет - из параметров for заголовка Forwarded
+func forwardedFor(h http.Header) []string {
+	var hops []string
+
+	for _, value := range h.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+	if len(hops) > 0 {
+		return hops
+	}
+
+	for _, value := range h.Values("Forwarded") {
+		for _, element := range strings.Split(value, ",") {
+			for _, pair := range strings.Split(element, ";") {
+				key, val, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if ok && strings.EqualFold(key, "for") {
+					hops = append(hops, val)
+				}
+			}
+		}
+	}
+
+	return hops
+}
+
+// parseHopIP разбирает адрес из заголовка или RemoteAddr: IP, IP:port, [IPv6]:port, в том числе в кавычках
+func parseHopIP(value string) netip.Addr {
+	value = strings.Trim(strings.TrimSpace(value), `"`)
+
+	if ip, err := netip.ParseAddr(value); err == nil {
+		return ip.Unmap()
+	}
+	if host, _, err := net.SplitHostPort(value); err == nil {
+		value = host
+	}
+	ip, err := netip.ParseAddr(strings.Trim(value, "[]"))
+	if err != nil {
+		return netip.Addr{}
+	}
+
+	return ip.Unmap()
+}
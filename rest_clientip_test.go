@@ -0,0 +1,114 @@
+package configo
+
+import (
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestRestClientIP(t *testing.T) {
+	rest := Rest{TrustedProxies: CIDRList{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("::1/128"),
+	}}
+
+	tests := []struct {
+		name    string
+		remote  string
+		headers map[string]string
+		want    string
+	}{
+		{"без прокси", "1.1.1.1:1000", nil, "1.1.1.1"},
+		{"заголовки от недоверенного адреса", "8.8.8.8:1000", map[string]string{"X-Forwarded-For": "1.1.1.1", "X-Real-IP": "2.2.2.2"}, "8.8.8.8"},
+		{"первый недоверенный справа", "10.0.0.1:1000", map[string]string{"X-Forwarded-For": "6.6.6.6, 1.1.1.1, 10.2.2.2"}, "1.1.1.1"},
+		{"все хопы доверенные", "10.0.0.1:1000", map[string]string{"X-Forwarded-For": "10.3.3.3, 10.2.2.2"}, "10.3.3.3"},
+		{"поврежденный хоп", "10.0.0.1:1000", map[string]string{"X-Forwarded-For": "1.1.1.1, garbage, 10.2.2.2"}, "10.2.2.2"},
+		{"хоп с портом", "10.0.0.1:1000", map[string]string{"X-Forwarded-For": "1.1.1.1:5555"}, "1.1.1.1"},
+		{"Forwarded", "10.0.0.1:1000", map[string]string{"Forwarded": `for=6.6.6.6;proto=https, for="[2001:db8::1]:4711", For=10.1.1.1`}, "2001:db8::1"},
+		{"Forwarded со скрытым адресом", "10.0.0.1:1000", map[string]string{"Forwarded": "for=_hidden, for=10.9.9.9"}, "10.9.9.9"},
+		{"X-Forwarded-For важнее Forwarded", "10.0.0.1:1000", map[string]string{"X-Forwarded-For": "1.1.1.1", "Forwarded": "for=2.2.2.2"}, "1.1.1.1"},
+		{"X-Real-IP", "10.0.0.1:1000", map[string]string{"X-Real-IP": "5.5.5.5"}, "5.5.5.5"},
+		{"IPv6 прокси", "[::1]:1000", map[string]string{"X-Real-IP": "5.5.5.5"}, "5.5.5.5"},
+		{"IPv4 в IPv6-записи", "[::ffff:10.0.0.1]:1000", map[string]string{"X-Forwarded-For": "::ffff:1.1.1.1"}, "1.1.1.1"},
+		{"некорректный RemoteAddr", "garbage", nil, "invalid IP"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.RemoteAddr = tt.remote
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			if got := rest.ClientIP(req).String(); got != tt.want {
+				t.Errorf("ClientIP() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCIDR(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"10.0.0.0/8", "10.0.0.0/8", false},
+		{"10.1.2.3/8", "10.0.0.0/8", false},
+		{" 192.168.1.5 ", "192.168.1.5/32", false},
+		{"::1", "::1/128", false},
+		{"2001:db8::/32", "2001:db8::/32", false},
+		{"::ffff:10.0.0.1", "10.0.0.1/32", false},
+		{"::ffff:10.0.0.0/104", "", true},
+		{"10.0.0.0/33", "", true},
+		{"nope", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseCIDR(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCIDR(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got.String() != tt.want {
+			t.Errorf("ParseCIDR(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestCIDRListUnmarshal(t *testing.T) {
+	var text CIDRList
+	if err := text.UnmarshalText([]byte("10.0.0.0/8, 1.1.1.1,")); err != nil {
+		t.Fatalf("UnmarshalText: %v", err)
+	}
+	if got := text.String(); got != "10.0.0.0/8,1.1.1.1/32" {
+		t.Errorf("UnmarshalText = %s", got)
+	}
+	if !text.Contains(netip.MustParseAddr("::ffff:10.2.3.4")) || text.Contains(netip.MustParseAddr("1.1.1.2")) {
+		t.Error("Contains работает неверно")
+	}
+
+	var cfg struct {
+		List CIDRList `yaml:"list"`
+	}
+	if err := yaml.Unmarshal([]byte("list: [10.0.0.0/8, '::1']"), &cfg); err != nil {
+		t.Fatalf("yaml: %v", err)
+	}
+	if got := cfg.List.String(); got != "10.0.0.0/8,::1/128" {
+		t.Errorf("yaml = %s", got)
+	}
+
+	err := yaml.Unmarshal([]byte("list:\n  - 10.0.0.0/8\n  - nope\n  - 10.0.0.0/33\n"), &cfg)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, want := range []string{`строка 3: некорректная подсеть "nope"`, `строка 4: некорректная подсеть "10.0.0.0/33"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error = %v, want containing %q", err, want)
+		}
+	}
+}
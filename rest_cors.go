@@ -0,0 +1,255 @@
+package configo
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+func (c *RestCORS) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	var errs []error
+
+	for i, origin := range c.AllowedOrigins {
+		switch origin = strings.TrimSpace(origin); origin {
+		case "":
+			continue
+		case "*":
+			if c.AllowCredentials {
+				errs = append(errs, fieldErrorf("allowedOrigins", "\"*\" нельзя использовать вместе с allowCredentials"))
+			}
+			continue
+		}
+		if err := validateOrigin(origin); err != nil {
+			errs = append(errs, fieldError(fmt.Sprintf("allowedOrigins[%d]", i), err))
+		}
+	}
+	if c.MaxAge < 0 {
+		errs = append(errs, fieldErrorf("maxAge", "не может быть отрицательным"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateOrigin проверяет origin вида scheme://host[:port], поддомен можно заменить на "*."
+func validateOrigin(origin string) error {
+	u, err := url.Parse(strings.Replace(origin, "://*.", "://wildcard.", 1))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("некорректный origin %q, ожидается scheme://host[:port]", origin)
+	}
+	if u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return fmt.Errorf("origin %q не должен содержать путь, параметры или учетные данные", origin)
+	}
+	if strings.Contains(u.Host, "*") {
+		return fmt.Errorf("в origin %q допустим только шаблон поддомена вида scheme://*.domain", origin)
+	}
+
+	return nil
+}
+
+// Middleware возвращает CORS-middleware из настроек.
+// Preflight-запросы обрабатываются сами и завершаются ответом 204, если не включен OptionsPassthrough.
+// Если Enabled выключен, запросы проходят без изменений.
+// Middleware можно получить и без MustLoad, поэтому настройки, не прошедшие Validate, вызывают панику:
+// например "*" вместе с allowCredentials позволил бы любому сайту делать запросы с учетными данными пользователя.
+func (c RestCORS) Middleware() func(http.Handler) http.Handler {
+	if !c.Enabled {
+		return func(next http.Handler) http.Handler {
+			return next
+		}
+	}
+	if err := c.Validate(); err != nil {
+		panic("Некорректные настройки CORS: " + err.Error())
+	}
+
+	p := newCorsPolicy(c)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				p.preflight(w, r)
+				if c.OptionsPassthrough {
+					next.ServeHTTP(w, r)
+				} else {
+					w.WriteHeader(http.StatusNoContent)
+				}
+				return
+			}
+
+			p.actual(w, r)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// corsPolicy разобранные для быстрой проверки настройки RestCORS
+type corsPolicy struct {
+	allowAll        bool
+	origins         []string
+	wildcards       [][2]string // Префикс и суффикс origin для шаблонов поддоменов
+	methods         []string
+	headers         []string
+	allowAllHeaders bool
+	exposed         string
+	credentials     bool
+	maxAge          string
+	debug           bool
+}
+
+func newCorsPolicy(c RestCORS) *corsPolicy {
+	p := &corsPolicy{
+		credentials: c.AllowCredentials,
+		debug:       c.Debug,
+		exposed:     strings.Join(c.ExposedHeaders, ", "),
+	}
+
+	for _, origin := range c.AllowedOrigins {
+		origin = strings.ToLower(strings.TrimSpace(origin))
+		switch {
+		case origin == "*":
+			p.allowAll = true
+		case strings.Contains(origin, "://*."):
+			prefix, suffix, _ := strings.Cut(origin, "*")
+			p.wildcards = append(p.wildcards, [2]string{prefix, suffix})
+		case origin != "":
+			p.origins = append(p.origins, origin)
+		}
+	}
+	for _, method := range c.AllowedMethods {
+		p.methods = append(p.methods, strings.ToUpper(strings.TrimSpace(method)))
+	}
+	for _, header := range c.AllowedHeaders {
+		header = strings.TrimSpace(header)
+		if header == "*" {
+			p.allowAllHeaders = true
+		}
+		p.headers = append(p.headers, http.CanonicalHeaderKey(header))
+	}
+	if seconds := int(c.MaxAge.Seconds()); seconds > 0 {
+		p.maxAge = strconv.Itoa(seconds)
+	}
+
+	return p
+}
+
+func (p *corsPolicy) preflight(w http.ResponseWriter, r *http.Request) {
+	h := w.Header()
+	h.Add("Vary", "Origin")
+	h.Add("Vary", "Access-Control-Request-Method")
+	h.Add("Vary", "Access-Control-Request-Headers")
+
+	origin := r.Header.Get("Origin")
+	if !p.originAllowed(origin) {
+		p.log("preflight отклонен: origin не разрешен", "origin", origin)
+		return
+	}
+
+	method := strings.ToUpper(r.Header.Get("Access-Control-Request-Method"))
+	if !slices.Contains(p.methods, method) {
+		p.log("preflight отклонен: метод не разрешен", "origin", origin, "method", method)
+		return
+	}
+
+	requested := parseHeaderList(r.Header.Get("Access-Control-Request-Headers"))
+	for _, header := range requested {
+		if !p.allowAllHeaders && !slices.Contains(p.headers, header) {
+			p.log("preflight отклонен: заголовок не разрешен", "origin", origin, "header", header)
+			return
+		}
+	}
+
+	h.Set("Access-Control-Allow-Origin", p.allowOrigin(origin))
+	h.Set("Access-Control-Allow-Methods", method)
+	if len(requested) > 0 {
+		h.Set("Access-Control-Allow-Headers", strings.Join(requested, ", "))
+	}
+	if p.credentials {
+		h.Set("Access-Control-Allow-Credentials", "true")
+	}
+	if p.maxAge != "" {
+		h.Set("Access-Control-Max-Age", p.maxAge)
+	}
+	p.log("preflight разрешен", "origin", origin, "method", method, "headers", requested)
+}
+
+func (p *corsPolicy) actual(w http.ResponseWriter, r *http.Request) {
+	h := w.Header()
+	h.Add("Vary", "Origin")
+
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return
+	}
+	if !p.originAllowed(origin) {
+		p.log("запрос без CORS-заголовков: origin не разрешен", "origin", origin, "method", r.Method)
+		return
+	}
+
+	h.Set("Access-Control-Allow-Origin", p.allowOrigin(origin))
+	if p.credentials {
+		h.Set("Access-Control-Allow-Credentials", "true")
+	}
+	if p.exposed != "" {
+		h.Set("Access-Control-Expose-Headers", p.exposed)
+	}
+}
+
+func (p *corsPolicy) originAllowed(origin string) bool {
+	if origin == "" {
+		return false
+	}
+	if p.allowAll {
+		return true
+	}
+
+	origin = strings.ToLower(origin)
+	if slices.Contains(p.origins, origin) {
+		return true
+	}
+	for _, w := range p.wildcards {
+		if len(origin) <= len(w[0])+len(w[1]) || !strings.HasPrefix(origin, w[0]) || !strings.HasSuffix(origin, w[1]) {
+			continue
+		}
+		if sub := origin[len(w[0]) : len(origin)-len(w[1])]; !strings.ContainsAny(sub, "/:@") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// allowOrigin возвращает значение Access-Control-Allow-Origin.
+// При разрешенных всех origin всегда "*": Middleware не допускает его вместе с credentials
+func (p *corsPolicy) allowOrigin(origin string) string {
+	if p.allowAll {
+		return "*"
+	}
+
+	return origin
+}
+
+func (p *corsPolicy) log(msg string, args ...any) {
+	if p.debug {
+		slog.Debug("cors: "+msg, args...)
+	}
+}
+
+// parseHeaderList разбирает список заголовков через запятую в канонический вид
+func parseHeaderList(value string) []string {
+	var headers []string
+	for _, header := range strings.Split(value, ",") {
+		if header = strings.TrimSpace(header); header != "" {
+			headers = append(headers, http.CanonicalHeaderKey(header))
+		}
+	}
+
+	return headers
+}
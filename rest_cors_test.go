@@ -0,0 +1,232 @@
+package configo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCorsOriginAllowed(t *testing.T) {
+	p := newCorsPolicy(RestCORS{
+		AllowedOrigins: []string{"https://*.example.com", "http://localhost:3000", "https://*.example.org:8443"},
+	})
+
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{"https://api.example.com", true},
+		{"https://a.b.example.com", true},
+		{"HTTPS://API.EXAMPLE.COM", true},
+		{"https://example.com", false},
+		{"http://api.example.com", false},
+		{"https://api.example.com.evil.com", false},
+		{"https://evil.com/.example.com", false},
+		{"https://x.evil.com:1.example.com", false},
+		{"https://user@x.example.com", false},
+		{"https://.example.com", false},
+		{"http://localhost:3000", true},
+		{"http://localhost:3001", false},
+		{"https://api.example.org:8443", true},
+		{"https://api.example.org", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := p.originAllowed(tt.origin); got != tt.want {
+			t.Errorf("originAllowed(%q) = %v, want %v", tt.origin, got, tt.want)
+		}
+	}
+}
+
+func TestRestCORSMiddleware(t *testing.T) {
+	cors := RestCORS{
+		Enabled:          true,
+		AllowedOrigins:   []string{"https://*.example.com"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization"},
+		ExposedHeaders:   []string{"X-Request-Id"},
+		AllowCredentials: true,
+		MaxAge:           5 * time.Minute,
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		headers     map[string]string
+		wantCode    int
+		wantHeaders map[string]string
+	}{
+		{
+			name:   "preflight разрешен",
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                         "https://app.example.com",
+				"Access-Control-Request-Method":  "post",
+				"Access-Control-Request-Headers": "content-type, authorization",
+			},
+			wantCode: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://app.example.com",
+				"Access-Control-Allow-Methods":     "POST",
+				"Access-Control-Allow-Headers":     "Content-Type, Authorization",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Max-Age":           "300",
+			},
+		},
+		{
+			name:   "preflight с запрещенным методом",
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://app.example.com",
+				"Access-Control-Request-Method": "DELETE",
+			},
+			wantCode:    http.StatusNoContent,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:   "preflight с запрещенным заголовком",
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                         "https://app.example.com",
+				"Access-Control-Request-Method":  "GET",
+				"Access-Control-Request-Headers": "X-Custom",
+			},
+			wantCode:    http.StatusNoContent,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:     "обычный запрос",
+			method:   http.MethodGet,
+			headers:  map[string]string{"Origin": "https://app.example.com"},
+			wantCode: http.StatusOK,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://app.example.com",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Expose-Headers":    "X-Request-Id",
+			},
+		},
+		{
+			name:        "чужой origin",
+			method:      http.MethodGet,
+			headers:     map[string]string{"Origin": "https://evil.com"},
+			wantCode:    http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:        "запрос без origin",
+			method:      http.MethodGet,
+			wantCode:    http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+	}
+
+	handler := cors.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("code = %d, want %d", rec.Code, tt.wantCode)
+			}
+			for k, want := range tt.wantHeaders {
+				if got := rec.Header().Get(k); got != want {
+					t.Errorf("%s = %q, want %q", k, got, want)
+				}
+			}
+			if vary := strings.Join(rec.Header().Values("Vary"), ","); !strings.Contains(vary, "Origin") {
+				t.Errorf("Vary = %q, want Origin", vary)
+			}
+		})
+	}
+}
+
+func TestRestCORSMiddlewareOptionsPassthrough(t *testing.T) {
+	cors := RestCORS{
+		Enabled:            true,
+		AllowedOrigins:     []string{"https://app.example.com"},
+		AllowedMethods:     []string{"GET"},
+		OptionsPassthrough: true,
+	}
+
+	called := false
+	handler := cors.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	req := httptest.NewRequest(http.MethodOptions, "/", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "GET")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if !called || rec.Code != http.StatusTeapot {
+		t.Errorf("preflight не передан дальше: called = %v, code = %d", called, rec.Code)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://app.example.com" {
+		t.Errorf("Access-Control-Allow-Origin = %q", got)
+	}
+}
+
+func TestRestCORSMiddlewareInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		cors RestCORS
+	}{
+		{"звездочка с credentials", RestCORS{Enabled: true, AllowedOrigins: []string{"*"}, AllowCredentials: true}},
+		{"некорректный origin", RestCORS{Enabled: true, AllowedOrigins: []string{"example.com"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			tt.cors.Middleware()
+		})
+	}
+}
+
+func TestRestCORSValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cors    RestCORS
+		wantErr string
+	}{
+		{"выключен", RestCORS{AllowedOrigins: []string{"bad"}}, ""},
+		{"корректный", RestCORS{Enabled: true, AllowedOrigins: []string{"https://*.example.com", "http://localhost:3000", ""}}, ""},
+		{"звездочка с credentials", RestCORS{Enabled: true, AllowedOrigins: []string{"*"}, AllowCredentials: true}, "allowCredentials"},
+		{"без схемы", RestCORS{Enabled: true, AllowedOrigins: []string{"example.com"}}, "allowedOrigins[0]"},
+		{"шаблон в середине", RestCORS{Enabled: true, AllowedOrigins: []string{"https://a.*.com"}}, "allowedOrigins[0]"},
+		{"путь", RestCORS{Enabled: true, AllowedOrigins: []string{"https://example.com/path"}}, "allowedOrigins[0]"},
+		{"отрицательный maxAge", RestCORS{Enabled: true, MaxAge: -time.Second}, "maxAge"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cors.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
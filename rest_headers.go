@@ -0,0 +1,105 @@
+package configo
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var referrerPolicies = []string{
+	"no-referrer", "no-referrer-when-downgrade", "origin", "origin-when-cross-origin",
+	"same-origin", "strict-origin", "strict-origin-when-cross-origin", "unsafe-url",
+}
+
+func (h *RestSecurityHeaders) Validate() error {
+	if !h.Enabled {
+		return nil
+	}
+
+	var errs []error
+
+	if h.HSTSMaxAgeSeconds < 0 {
+		errs = append(errs, fieldErrorf("hstsMaxAgeSeconds", "не может быть отрицательным"))
+	}
+	if h.HSTSPreload && (!h.HSTSIncludeSubdomains || h.HSTSMaxAgeSeconds < 31536000) {
+		errs = append(errs, fieldErrorf("hstsPreload", "требует hstsIncludeSubdomains и hstsMaxAgeSeconds не меньше 31536000"))
+	}
+
+	switch strings.ToUpper(h.FrameOptions) {
+	case "", "DENY", "SAMEORIGIN":
+	default:
+		errs = append(errs, fieldErrorf("frameOptions", "некорректное значение %q, допустимо: DENY, SAMEORIGIN", h.FrameOptions))
+	}
+
+	switch h.XSSProtection {
+	case "", "0", "1", "1; mode=block":
+	default:
+		errs = append(errs, fieldErrorf("xssProtection", "некорректное значение %q, допустимо: 0, 1, 1; mode=block", h.XSSProtection))
+	}
+
+	if h.ReferrerPolicy != "" && !slices.Contains(referrerPolicies, h.ReferrerPolicy) {
+		errs = append(errs, fieldErrorf("referrerPolicy", "некорректное значение %q, допустимо: %v", h.ReferrerPolicy, referrerPolicies))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Middleware возвращает middleware, выставляющее заголовки безопасности из настроек.
+// Пустые значения не выставляются, Strict-Transport-Security отправляется только по TLS.
+// Если Enabled выключен, запросы проходят без изменений.
+func (h RestSecurityHeaders) Middleware() func(http.Handler) http.Handler {
+	if !h.Enabled {
+		return func(next http.Handler) http.Handler {
+			return next
+		}
+	}
+
+	headers := make(map[string]string)
+	setHeader := func(name, value string) {
+		if value != "" {
+			headers[name] = value
+		}
+	}
+
+	if h.ContentTypeNosniff {
+		setHeader("X-Content-Type-Options", "nosniff")
+	}
+	setHeader("X-Frame-Options", strings.ToUpper(h.FrameOptions))
+	setHeader("X-XSS-Protection", h.XSSProtection)
+	setHeader("Content-Security-Policy", h.ContentSecurityPolicy)
+	setHeader("Referrer-Policy", h.ReferrerPolicy)
+	setHeader("Permissions-Policy", h.PermissionsPolicy)
+
+	hsts := h.hstsValue()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for name, value := range headers {
+				w.Header().Set(name, value)
+			}
+			if hsts != "" && r.TLS != nil {
+				w.Header().Set("Strict-Transport-Security", hsts)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (h RestSecurityHeaders) hstsValue() string {
+	if h.HSTSMaxAgeSeconds <= 0 {
+		return ""
+	}
+
+	value := "max-age=" + strconv.Itoa(h.HSTSMaxAgeSeconds)
+	if h.HSTSIncludeSubdomains {
+		value += "; includeSubDomains"
+	}
+	if h.HSTSPreload {
+		value += "; preload"
+	}
+
+	return value
+}
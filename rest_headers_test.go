@@ -0,0 +1,157 @@
+package configo
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRestSecurityHeadersMiddleware(t *testing.T) {
+	full := RestSecurityHeaders{
+		Enabled:               true,
+		HSTSMaxAgeSeconds:     31536000,
+		HSTSIncludeSubdomains: true,
+		HSTSPreload:           true,
+		ContentTypeNosniff:    true,
+		FrameOptions:          "deny",
+		XSSProtection:         "0",
+		ContentSecurityPolicy: "default-src 'self'",
+		ReferrerPolicy:        "no-referrer",
+		PermissionsPolicy:     "geolocation=()",
+	}
+
+	tests := []struct {
+		name        string
+		headers     RestSecurityHeaders
+		tls         bool
+		wantHeaders map[string]string // Пустое значение - заголовка быть не должно
+	}{
+		{
+			name:    "все заголовки по TLS",
+			headers: full,
+			tls:     true,
+			wantHeaders: map[string]string{
+				"Strict-Transport-Security": "max-age=31536000; includeSubDomains; preload",
+				"X-Content-Type-Options":    "nosniff",
+				"X-Frame-Options":           "DENY",
+				"X-XSS-Protection":          "0",
+				"Content-Security-Policy":   "default-src 'self'",
+				"Referrer-Policy":           "no-referrer",
+				"Permissions-Policy":        "geolocation=()",
+			},
+		},
+		{
+			name:    "без TLS нет HSTS",
+			headers: full,
+			wantHeaders: map[string]string{
+				"Strict-Transport-Security": "",
+				"X-Frame-Options":           "DENY",
+			},
+		},
+		{
+			name:        "HSTS без поддоменов",
+			headers:     RestSecurityHeaders{Enabled: true, HSTSMaxAgeSeconds: 600},
+			tls:         true,
+			wantHeaders: map[string]string{"Strict-Transport-Security": "max-age=600"},
+		},
+		{
+			name:    "пустые значения не выставляются",
+			headers: RestSecurityHeaders{Enabled: true},
+			tls:     true,
+			wantHeaders: map[string]string{
+				"Strict-Transport-Security": "",
+				"X-Content-Type-Options":    "",
+				"X-Frame-Options":           "",
+				"Content-Security-Policy":   "",
+				"Permissions-Policy":        "",
+			},
+		},
+		{
+			name:    "выключено",
+			headers: func() RestSecurityHeaders { h := full; h.Enabled = false; return h }(),
+			tls:     true,
+			wantHeaders: map[string]string{
+				"Strict-Transport-Security": "",
+				"X-Frame-Options":           "",
+				"Content-Security-Policy":   "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := tt.headers.Middleware()(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+				called = true
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.tls {
+				req.TLS = &tls.ConnectionState{}
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if !called {
+				t.Error("следующий handler не вызван")
+			}
+			for name, want := range tt.wantHeaders {
+				if got := rec.Header().Get(name); got != want {
+					t.Errorf("%s = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRestSecurityHeadersValidate(t *testing.T) {
+	valid := RestSecurityHeaders{
+		Enabled:               true,
+		HSTSMaxAgeSeconds:     31536000,
+		HSTSIncludeSubdomains: true,
+		FrameOptions:          "SAMEORIGIN",
+		XSSProtection:         "0",
+		ReferrerPolicy:        "strict-origin-when-cross-origin",
+	}
+	with := func(fn func(h *RestSecurityHeaders)) RestSecurityHeaders {
+		h := valid
+		fn(&h)
+		return h
+	}
+
+	tests := []struct {
+		name    string
+		headers RestSecurityHeaders
+		wantErr string
+	}{
+		{"по умолчанию", valid, ""},
+		{"frame options deny в нижнем регистре", with(func(h *RestSecurityHeaders) { h.FrameOptions = "deny" }), ""},
+		{"frame options allow-from", with(func(h *RestSecurityHeaders) { h.FrameOptions = "ALLOW-FROM https://a.com" }), "frameOptions"},
+		{"xss protection mode=block", with(func(h *RestSecurityHeaders) { h.XSSProtection = "1; mode=block" }), ""},
+		{"xss protection некорректно", with(func(h *RestSecurityHeaders) { h.XSSProtection = "2" }), "xssProtection"},
+		{"referrer policy пустая", with(func(h *RestSecurityHeaders) { h.ReferrerPolicy = "" }), ""},
+		{"referrer policy некорректная", with(func(h *RestSecurityHeaders) { h.ReferrerPolicy = "never" }), "referrerPolicy"},
+		{"отрицательный max-age", with(func(h *RestSecurityHeaders) { h.HSTSMaxAgeSeconds = -1 }), "hstsMaxAgeSeconds"},
+		{"preload", with(func(h *RestSecurityHeaders) { h.HSTSPreload = true }), ""},
+		{"preload без поддоменов", with(func(h *RestSecurityHeaders) { h.HSTSPreload, h.HSTSIncludeSubdomains = true, false }), "hstsPreload"},
+		{"preload с коротким max-age", with(func(h *RestSecurityHeaders) { h.HSTSPreload, h.HSTSMaxAgeSeconds = true, 600 }), "hstsPreload"},
+		{"выключено", RestSecurityHeaders{FrameOptions: "nonsense"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.headers.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package configo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+func (c *RestRateLimitConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	var errs []error
+
+	if c.RPS <= 0 {
+		errs = append(errs, fieldErrorf("rps", "должно быть больше 0"))
+	}
+	if c.Burst < 1 {
+		errs = append(errs, fieldErrorf("burst", "должно быть не меньше 1"))
+	}
+	if c.CleanupInterval <= 0 {
+		errs = append(errs, fieldErrorf("cleanupInterval", "должно быть больше 0"))
+	}
+
+	for i, route := range c.Routes {
+		path := fmt.Sprintf("routes[%d]", i)
+		if !strings.HasPrefix(route.Path, "/") {
+			errs = append(errs, fieldErrorf(joinPath(path, "path"), "должен начинаться с /"))
+		}
+		if route.RPS <= 0 {
+			errs = append(errs, fieldErrorf(joinPath(path, "rps"), "должно быть больше 0"))
+		}
+		if route.Burst < 1 {
+			errs = append(errs, fieldErrorf(joinPath(path, "burst"), "должно быть не меньше 1"))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// RateLimiter возвращает middleware, ограничивающее частоту запросов с одного IP клиента
+// по алгоритму token bucket. IP определяется с учетом TrustedProxies.
+// Клиенты без IP, например на unix-сокете, не ограничиваются: иначе все они делили бы один счетчик.
+// Простаивающие счетчики удаляются раз в CleanupInterval, пока не завершится ctx.
+// Если RateLimit.Enabled выключен, запросы проходят без изменений.
+func (r Rest) RateLimiter(ctx context.Context) func(http.Handler) http.Handler {
+	c := r.RateLimit
+	if !c.Enabled {
+		return func(next http.Handler) http.Handler {
+			return next
+		}
+	}
+
+	l := newRateLimiter(c)
+	go l.cleanup(ctx, c.CleanupInterval)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			ip := r.ClientIP(req)
+			if !ip.IsValid() || l.exempt.Contains(ip) {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			key, limit := l.match(req)
+			allowed, remaining, wait, reset := l.take(key+"|"+ip.String(), limit)
+
+			h := w.Header()
+			h.Set("RateLimit-Limit", strconv.Itoa(limit.burst))
+			h.Set("RateLimit-Remaining", strconv.Itoa(remaining))
+			h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(reset)))
+
+			if !allowed {
+				h.Set("Retry-After", strconv.Itoa(max(ceilSeconds(wait), 1)))
+				http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+type rateLimit struct {
+	rps   float64
+	burst int
+}
+
+type rateLimitRoute struct {
+	path   string
+	method string
+	limit  rateLimit
+}
+
+// tokenBucket счетчик токенов одного клиента, пополняется со скоростью rps до burst
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+	limit  rateLimit
+}
+
+type rateLimiter struct {
+	limit  rateLimit
+	routes []rateLimitRoute
+	exempt CIDRList
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	now     func() time.Time
+}
+
+func newRateLimiter(c RestRateLimitConfig) *rateLimiter {
+	l := &rateLimiter{
+		limit:   rateLimit{rps: c.RPS, burst: c.Burst},
+		exempt:  c.ExemptCIDRs,
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+	for _, route := range c.Routes {
+		l.routes = append(l.routes, rateLimitRoute{
+			path:   route.Path,
+			method: strings.ToUpper(route.Method),
+			limit:  rateLimit{rps: route.RPS, burst: route.Burst},
+		})
+	}
+
+	return l
+}
+
+// match выбирает лимит для запроса: маршрут с самым длинным подходящим префиксом или общий лимит
+func (l *rateLimiter) match(req *http.Request) (string, rateLimit) {
+	best := -1
+	for i, route := range l.routes {
+		if route.method != "" && route.method != req.Method {
+			continue
+		}
+		if !matchPathPrefix(req.URL.Path, route.path) {
+			continue
+		}
+		if best < 0 || len(route.path) > len(l.routes[best].path) {
+			best = i
+		}
+	}
+
+	if best < 0 {
+		return "", l.limit
+	}
+
+	return strconv.Itoa(best), l.routes[best].limit
+}
+
+// matchPathPrefix проверяет префикс по границе сегментов: /api подходит для /api/users, но не для /apiary
+func matchPathPrefix(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// take списывает токен. Возвращает, разрешен ли запрос, сколько токенов осталось,
+// через сколько появится следующий токен и через сколько счетчик заполнится полностью
+func (l *rateLimiter) take(key string, limit rateLimit) (bool, int, time.Duration, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: float64(limit.burst), last: now, limit: limit}
+		l.buckets[key] = b
+	}
+	b.refill(now)
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+
+	wait := time.Duration(0)
+	if b.tokens < 1 {
+		wait = secondsDuration((1 - b.tokens) / limit.rps)
+	}
+	reset := secondsDuration((float64(limit.burst) - b.tokens) / limit.rps)
+
+	return allowed, int(b.tokens), wait, reset
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	b.tokens = math.Min(float64(b.limit.burst), b.tokens+elapsed*b.limit.rps)
+	b.last = now
+}
+
+// cleanup раз в interval удаляет счетчики, которые успели заполниться: они не отличаются от новых
+func (l *rateLimiter) cleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			l.evict()
+		}
+	}
+}
+
+func (l *rateLimiter) evict() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	for key, b := range l.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.limit.burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func secondsDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
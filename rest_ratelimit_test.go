@@ -0,0 +1,195 @@
+package configo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterTake(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := newRateLimiter(RestRateLimitConfig{RPS: 2, Burst: 3})
+	l.now = func() time.Time { return now }
+	limit := l.limit
+
+	type step struct {
+		advance       time.Duration
+		wantAllowed   bool
+		wantRemaining int
+		wantWait      time.Duration
+		wantReset     time.Duration
+	}
+	steps := []step{
+		{0, true, 2, 0, 500 * time.Millisecond},
+		{0, true, 1, 0, time.Second},
+		{0, true, 0, 500 * time.Millisecond, 1500 * time.Millisecond},
+		{0, false, 0, 500 * time.Millisecond, 1500 * time.Millisecond},
+		{250 * time.Millisecond, false, 0, 250 * time.Millisecond, 1250 * time.Millisecond},
+		{250 * time.Millisecond, true, 0, 500 * time.Millisecond, 1500 * time.Millisecond},
+		// За 10 секунд счетчик заполняется только до burst
+		{10 * time.Second, true, 2, 0, 500 * time.Millisecond},
+	}
+
+	for i, s := range steps {
+		now = now.Add(s.advance)
+		allowed, remaining, wait, reset := l.take("client", limit)
+		if allowed != s.wantAllowed || remaining != s.wantRemaining || wait != s.wantWait || reset != s.wantReset {
+			t.Errorf("шаг %d: take() = (%v, %d, %s, %s), want (%v, %d, %s, %s)", i,
+				allowed, remaining, wait, reset, s.wantAllowed, s.wantRemaining, s.wantWait, s.wantReset)
+		}
+	}
+
+	if allowed, _, _, _ := l.take("other", limit); !allowed {
+		t.Error("у другого клиента свой счетчик")
+	}
+}
+
+func TestRateLimiterEvict(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := newRateLimiter(RestRateLimitConfig{RPS: 1, Burst: 2})
+	l.now = func() time.Time { return now }
+
+	l.take("idle", l.limit)
+	l.take("busy", l.limit)
+	now = now.Add(time.Second)
+	l.take("busy", l.limit)
+	l.take("busy", l.limit)
+	now = now.Add(500 * time.Millisecond)
+
+	l.evict()
+
+	if _, ok := l.buckets["idle"]; ok {
+		t.Error("заполнившийся счетчик не удален")
+	}
+	if _, ok := l.buckets["busy"]; !ok {
+		t.Error("незаполненный счетчик удален, клиент получил бы burst заново")
+	}
+}
+
+func TestRateLimiterMatch(t *testing.T) {
+	l := newRateLimiter(RestRateLimitConfig{
+		RPS:   10,
+		Burst: 10,
+		Routes: []RestRateLimitRoute{
+			{Path: "/api", RPS: 1, Burst: 1},
+			{Path: "/api/login", Method: "post", RPS: 2, Burst: 2},
+			{Path: "/static/", RPS: 3, Burst: 3},
+		},
+	})
+
+	tests := []struct {
+		method    string
+		path      string
+		wantBurst int
+	}{
+		{http.MethodGet, "/api", 1},
+		{http.MethodGet, "/api/users", 1},
+		{http.MethodGet, "/apiary", 10},
+		{http.MethodGet, "/api-docs", 10},
+		{http.MethodPost, "/api/login", 2},
+		{http.MethodPost, "/api/login/sso", 2},
+		{http.MethodGet, "/api/login", 1},
+		{http.MethodPost, "/api/loginx", 1},
+		{http.MethodGet, "/static", 3},
+		{http.MethodGet, "/static/app.js", 3},
+		{http.MethodGet, "/other", 10},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		if _, limit := l.match(req); limit.burst != tt.wantBurst {
+			t.Errorf("%s %s: burst = %d, want %d", tt.method, tt.path, limit.burst, tt.wantBurst)
+		}
+	}
+}
+
+func TestRestRateLimiter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rest := Rest{
+		TrustedProxies: CIDRList{netip.MustParsePrefix("10.0.0.0/8")},
+		RateLimit: RestRateLimitConfig{
+			Enabled:         true,
+			RPS:             0.5,
+			Burst:           2,
+			CleanupInterval: time.Minute,
+			ExemptCIDRs:     CIDRList{netip.MustParsePrefix("192.168.0.0/16")},
+		},
+	}
+	handler := rest.RateLimiter(ctx)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	do := func(remote, forwardedFor string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = remote
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for i := range 2 {
+		if rec := do("1.1.1.1:1000", ""); rec.Code != http.StatusOK {
+			t.Fatalf("запрос %d: code = %d", i, rec.Code)
+		}
+	}
+
+	// Тот же клиент через доверенный прокси делит счетчик с прямыми запросами
+	rec := do("10.0.0.1:1000", "1.1.1.1")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("code = %d, want 429", rec.Code)
+	}
+	want := map[string]string{
+		"Retry-After":         "2",
+		"RateLimit-Limit":     "2",
+		"RateLimit-Remaining": "0",
+		"RateLimit-Reset":     "4",
+	}
+	for k, v := range want {
+		if got := rec.Header().Get(k); got != v {
+			t.Errorf("%s = %q, want %q", k, got, v)
+		}
+	}
+
+	// Подмена X-Forwarded-For от недоверенного адреса не дает нового счетчика
+	if rec := do("1.1.1.1:1000", "9.9.9.9"); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("недоверенный X-Forwarded-For: code = %d, want 429", rec.Code)
+	}
+
+	for range 5 {
+		if rec := do("192.168.1.1:1000", ""); rec.Code != http.StatusOK || rec.Header().Get("RateLimit-Limit") != "" {
+			t.Fatalf("исключенный клиент ограничен: code = %d", rec.Code)
+		}
+	}
+
+	// Клиенты без IP, например на unix-сокете, не ограничиваются вместо общего на всех счетчика
+	for _, remote := range []string{"@", "", "/run/app.sock", "@", ""} {
+		if rec := do(remote, ""); rec.Code != http.StatusOK || rec.Header().Get("RateLimit-Limit") != "" {
+			t.Fatalf("клиент без IP %q ограничен: code = %d", remote, rec.Code)
+		}
+	}
+}
+
+func TestRestRateLimitConfigValidate(t *testing.T) {
+	valid := RestRateLimitConfig{Enabled: true, RPS: 1, Burst: 1, CleanupInterval: time.Minute}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	invalid := RestRateLimitConfig{Enabled: true, Routes: []RestRateLimitRoute{{Path: "api"}}}
+	err := invalid.Validate()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, path := range []string{"rps", "burst", "cleanupInterval", "routes[0].path", "routes[0].rps", "routes[0].burst"} {
+		if !strings.Contains(err.Error(), path+":") {
+			t.Errorf("нет ошибки для %s: %v", path, err)
+		}
+	}
+}
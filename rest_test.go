@@ -0,0 +1,231 @@
+package configo
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRestNewServer(t *testing.T) {
+	r := Rest{
+		Host:               "127.0.0.1",
+		Port:               8080,
+		ReadTimeout:        time.Second,
+		WriteTimeout:       2 * time.Second,
+		IdleTimeout:        3 * time.Second,
+		BasePath:           "/api/v1/",
+		MaxRequestBodySize: 8,
+	}
+
+	srv, err := r.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		_, _ = io.WriteString(w, req.URL.Path+":"+string(body))
+	}))
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	if srv.Addr != "127.0.0.1:8080" || srv.ReadTimeout != time.Second || srv.WriteTimeout != 2*time.Second || srv.IdleTimeout != 3*time.Second {
+		t.Errorf("server = addr %s, read %s, write %s, idle %s", srv.Addr, srv.ReadTimeout, srv.WriteTimeout, srv.IdleTimeout)
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		body     string
+		wantCode int
+		wantBody string
+	}{
+		{"корень base path", "/api/v1", "", http.StatusOK, ":"},
+		{"вложенный путь", "/api/v1/users", "hi", http.StatusOK, "/users:hi"},
+		{"похожий префикс", "/api/v10/users", "", http.StatusNotFound, ""},
+		{"вне base path", "/health", "", http.StatusNotFound, ""},
+		{"тело в пределах лимита", "/api/v1/echo", "12345678", http.StatusOK, "/echo:12345678"},
+		{"тело больше лимита", "/api/v1/echo", "123456789", http.StatusRequestEntityTooLarge, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			srv.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body)))
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("code = %d, want %d", rec.Code, tt.wantCode)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestRestNewServerHandlerTimeout(t *testing.T) {
+	slow := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		select {
+		case <-req.Context().Done():
+		case <-time.After(time.Second):
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+
+	srv, err := Rest{HandlerTimeout: 20 * time.Millisecond}.NewServer(slow)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("code = %d, want 503", rec.Code)
+	}
+
+	// Без HandlerTimeout handler не оборачивается и сохраняет http.Flusher
+	srv, err = Rest{}.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if _, ok := w.(http.Flusher); !ok {
+			t.Error("ResponseWriter не реализует http.Flusher")
+		}
+	}))
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	srv.Handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestRestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rest    Rest
+		wantErr string
+	}{
+		{"по умолчанию", Rest{BasePath: "/", WriteTimeout: 10 * time.Second}, ""},
+		{"base path без слеша", Rest{BasePath: "api"}, "basePath"},
+		{"отрицательный размер тела", Rest{MaxRequestBodySize: -1}, "maxRequestBodySize"},
+		{"handler timeout больше write timeout", Rest{HandlerTimeout: 15 * time.Second, WriteTimeout: 10 * time.Second}, "handlerTimeout"},
+		{"handler timeout меньше write timeout", Rest{HandlerTimeout: 5 * time.Second, WriteTimeout: 10 * time.Second}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rest.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRestRun(t *testing.T) {
+	tests := []struct {
+		name            string
+		shutdownTimeout time.Duration
+	}{
+		{"с таймаутом завершения", time.Second},
+		{"без таймаута завершения", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			port := freePort(t)
+			r := Rest{Host: "127.0.0.1", Port: port, ShutdownTimeout: tt.shutdownTimeout}
+
+			started := make(chan struct{})
+			release := make(chan struct{})
+			handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				close(started)
+				<-release
+				_, _ = io.WriteString(w, "done")
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			runErr := make(chan error, 1)
+			go func() { runErr <- r.Run(ctx, handler) }()
+
+			respBody := make(chan string, 1)
+			go func() {
+				resp, err := getWithRetry("http://" + r.Addr() + "/")
+				if err != nil {
+					respBody <- "error: " + err.Error()
+					return
+				}
+				defer resp.Body.Close()
+				body, _ := io.ReadAll(resp.Body)
+				respBody <- string(body)
+			}()
+
+			<-started
+			cancel()
+
+			// Запрос в процессе обработки не обрывается при завершении
+			select {
+			case err := <-runErr:
+				t.Fatalf("Run завершился до окончания запроса: %v", err)
+			case <-time.After(50 * time.Millisecond):
+			}
+			close(release)
+
+			if body := <-respBody; body != "done" {
+				t.Errorf("body = %q, want done", body)
+			}
+			select {
+			case err := <-runErr:
+				if err != nil {
+					t.Errorf("Run: %v", err)
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatal("Run не завершился после отмены ctx")
+			}
+		})
+	}
+}
+
+func TestRestRunListenError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	r := Rest{Host: "127.0.0.1", Port: Port(ln.Addr().(*net.TCPAddr).Port)}
+	if err := r.Run(context.Background(), http.NotFoundHandler()); err == nil {
+		t.Error("expected error for busy port")
+	}
+}
+
+func freePort(t *testing.T) Port {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	return Port(ln.Addr().(*net.TCPAddr).Port)
+}
+
+func getWithRetry(url string) (*http.Response, error) {
+	var err error
+	for range 100 {
+		var resp *http.Response
+		if resp, err = http.Get(url); err == nil {
+			return resp, nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	return nil, err
+}
@@ -0,0 +1,143 @@
+package configo
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+)
+
+func (s *S3) loadEnv(prefix string) error {
+	loadURLEnv(&s.URL, prefix)
+	return nil
+}
+
+func (s *S3) prepare(_ *loadContext) error {
+	return s.parseURL()
+}
+
+func (s *S3) parseURL() error {
+	if s.URL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return fieldError("url", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fieldErrorf("url", "неподдерживаемая схема %q, ожидается http или https", u.Scheme)
+	}
+
+	endpoint := url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}
+	secretKey, _ := u.User.Password()
+	query := u.Query()
+
+	return errors.Join(
+		mergeField(&s.Endpoint, endpoint.String(), "endpoint"),
+		mergeField(&s.Region, query.Get("region"), "region"),
+		mergeField(&s.Bucket, query.Get("bucket"), "bucket"),
+		mergeField(&s.AccessKey, u.User.Username(), "accessKey"),
+		mergeField(&s.SecretKey, Secret(secretKey), "secretKey"),
+	)
+}
+
+func (s *S3) Validate() error {
+	var errs []error
+
+	if s.Endpoint == "" {
+		errs = append(errs, requiredError("endpoint"))
+	} else if err := validateURL(s.Endpoint, "http", "https"); err != nil {
+		errs = append(errs, fieldError("endpoint", err))
+	}
+	if s.ProxyUrl != "" {
+		if err := validateURL(s.ProxyUrl, "http", "https", "socks5"); err != nil {
+			errs = append(errs, fieldError("proxyUrl", err))
+		}
+	}
+
+	if s.Region == "" {
+		errs = append(errs, requiredError("region"))
+	}
+	if s.AccessKey == "" {
+		errs = append(errs, requiredError("accessKey"))
+	}
+	if s.SecretKey == "" {
+		errs = append(errs, requiredError("secretKey"))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(s.Buckets)) {
+		if s.Buckets[name] == "" {
+			errs = append(errs, requiredError("buckets."+name))
+		}
+	}
+
+	if s.PresignTTL <= 0 || s.PresignTTL.Duration() > Week {
+		errs = append(errs, fieldErrorf("presignTtl", "должно быть больше нуля и не больше 7d"))
+	}
+	if s.ConnectTimeout < 0 {
+		errs = append(errs, fieldErrorf("connectTimeout", "не может быть отрицательным"))
+	}
+	if s.RequestTimeout < 0 {
+		errs = append(errs, fieldErrorf("requestTimeout", "не может быть отрицательным"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// EndpointURL возвращает разобранный Endpoint
+func (s S3) EndpointURL() (*url.URL, error) {
+	return url.Parse(s.Endpoint)
+}
+
+// ProxyFunc возвращает функцию для http.Transport.Proxy.
+// Если ProxyUrl не задан, возвращает nil - запросы идут напрямую.
+func (s S3) ProxyFunc() func(*http.Request) (*url.URL, error) {
+	if s.ProxyUrl == "" {
+		return nil
+	}
+
+	proxy, err := url.Parse(s.ProxyUrl)
+
+	return func(*http.Request) (*url.URL, error) {
+		return proxy, err
+	}
+}
+
+// BucketFor возвращает имя бакета по логическому имени из Buckets.
+// Для пустого имени возвращается Bucket.
+func (s S3) BucketFor(name string) (string, error) {
+	if name == "" {
+		if s.Bucket == "" {
+			return "", errors.New("бакет по умолчанию не задан")
+		}
+		return s.Bucket, nil
+	}
+
+	bucket, ok := s.Buckets[name]
+	if !ok {
+		return "", fmt.Errorf("неизвестный бакет %q", name)
+	}
+
+	return bucket, nil
+}
+
+// validateURL проверяет, что value - абсолютный url с хостом и одной из схем
+func validateURL(value string, schemes ...string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(schemes, u.Scheme) {
+		return fmt.Errorf("неподдерживаемая схема %q, допустимо: %v", u.Scheme, schemes)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("не указан хост в %q", value)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package configo
+
+import "log/slog"
+
+const secretMask = "******"
+
+// Secret строка с чувствительным значением (пароль, ключ).
+// При выводе через fmt, slog, yaml и json значение маскируется, исходное доступно через Value.
+// Поэтому конфиг, сохраненный через yaml.Marshal или json.Marshal, содержит маску вместо значения
+// и не загрузится обратно с теми же данными: для сохранения секретов подставляйте Value явно.
+type Secret string
+
+func (s Secret) Value() string {
+	return string(s)
+}
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+
+	return secretMask
+}
+
+func (s Secret) GoString() string {
+	return `configo.Secret("` + s.String() + `")`
+}
+
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(s.String())
+}
+
+// MarshalText возвращает маску, а не значение, см. описание Secret
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
@@ -0,0 +1,94 @@
+package configo
+
+import (
+	"errors"
+	"net/url"
+	"path"
+	"strings"
+)
+
+func (s *Sentry) prepare(lc *loadContext) error {
+	if err := s.parseURL(); err != nil {
+		return err
+	}
+
+	if s.Environment == "" {
+		s.Environment = lc.env.String()
+	}
+	if s.Release == "" && lc.app != nil {
+		s.Release = lc.app.Name + "@" + lc.app.Version
+	}
+
+	return nil
+}
+
+func (s *Sentry) parseURL() error {
+	if s.URL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return fieldError("url", err)
+	}
+
+	if u.Host == "" || u.User.Username() == "" || strings.Trim(u.Path, "/") == "" {
+		return fieldErrorf("url", "ожидается формат https://key@host/projectId")
+	}
+
+	prefix, projectID := path.Split(strings.TrimSuffix(u.Path, "/"))
+	host := u.Host + strings.TrimSuffix(prefix, "/")
+	if u.Scheme != "https" {
+		host = u.Scheme + "://" + host
+	}
+
+	return errors.Join(
+		mergeField(&s.Host, host, "host"),
+		mergeField(&s.Key, u.User.Username(), "key"),
+		mergeField(&s.ProjectID, projectID, "projectId"),
+	)
+}
+
+func (s *Sentry) Validate() error {
+	var errs []error
+
+	if s.Host == "" {
+		errs = append(errs, requiredError("host"))
+	}
+	if s.Key == "" {
+		errs = append(errs, requiredError("key"))
+	}
+	if s.ProjectID == "" {
+		errs = append(errs, requiredError("projectId"))
+	}
+	if s.SampleRate < 0 || s.SampleRate > 1 {
+		errs = append(errs, fieldErrorf("sampleRate", "должно быть от 0 до 1"))
+	}
+	if s.TracesSampleRate < 0 || s.TracesSampleRate > 1 {
+		errs = append(errs, fieldErrorf("tracesSampleRate", "должно быть от 0 до 1"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// DSN собирает DSN для sentry-go из Host, Key и ProjectID.
+// Если в Host не указана схема, используется https.
+func (s Sentry) DSN() string {
+	scheme, host, found := strings.Cut(s.Host, "://")
+	if !found {
+		scheme, host = "https", s.Host
+	}
+
+	host, prefix, _ := strings.Cut(host, "/")
+
+	u := url.URL{
+		Scheme: scheme,
+		User:   url.User(s.Key),
+		Host:   host,
+	}
+	if p := path.Join(prefix, s.ProjectID); p != "" {
+		u.Path = "/" + p
+	}
+
+	return u.String()
+}
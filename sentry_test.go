@@ -0,0 +1,112 @@
+package configo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSentryParseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		sentry  Sentry
+		want    Sentry
+		wantErr string
+	}{
+		{
+			name:   "https",
+			sentry: Sentry{URL: "https://key@o1.ingest.sentry.io/42"},
+			want:   Sentry{Host: "o1.ingest.sentry.io", Key: "key", ProjectID: "42"},
+		},
+		{
+			name:   "порт и префикс пути",
+			sentry: Sentry{URL: "https://key@sentry.local:9000/sentry/42"},
+			want:   Sentry{Host: "sentry.local:9000/sentry", Key: "key", ProjectID: "42"},
+		},
+		{
+			name:   "http",
+			sentry: Sentry{URL: "http://key@localhost:9000/7"},
+			want:   Sentry{Host: "http://localhost:9000", Key: "key", ProjectID: "7"},
+		},
+		{
+			name:   "совпадает с полями",
+			sentry: Sentry{URL: "https://key@sentry.io/42", Key: "key", ProjectID: "42"},
+			want:   Sentry{Host: "sentry.io", Key: "key", ProjectID: "42"},
+		},
+		{
+			name:    "противоречит полю",
+			sentry:  Sentry{URL: "https://key@sentry.io/42", ProjectID: "43"},
+			wantErr: "projectId",
+		},
+		{name: "без проекта", sentry: Sentry{URL: "https://key@sentry.io"}, wantErr: "ожидается формат"},
+		{name: "без ключа", sentry: Sentry{URL: "https://sentry.io/42"}, wantErr: "ожидается формат"},
+		{name: "пустой", sentry: Sentry{Host: "sentry.io"}, want: Sentry{Host: "sentry.io"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sentry.parseURL()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := tt.sentry
+			got.URL = ""
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSentryDSNRoundTrip(t *testing.T) {
+	for _, dsn := range []string{
+		"https://key@o1.ingest.sentry.io/42",
+		"https://key@sentry.local:9000/sentry/42",
+		"https://key@sentry.local/a/b/42",
+		"http://key@localhost:9000/7",
+	} {
+		t.Run(dsn, func(t *testing.T) {
+			s := Sentry{URL: dsn}
+			if err := s.parseURL(); err != nil {
+				t.Fatalf("parseURL: %v", err)
+			}
+			if got := s.DSN(); got != dsn {
+				t.Errorf("DSN() = %q, want %q", got, dsn)
+			}
+		})
+	}
+}
+
+func TestSentryValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		sentry  Sentry
+		wantErr string
+	}{
+		{"заполнено", Sentry{Host: "sentry.io", Key: "k", ProjectID: "1", SampleRate: 1}, ""},
+		{"без проекта", Sentry{Host: "sentry.io", Key: "k"}, "projectId"},
+		{"без ключа", Sentry{Host: "sentry.io", ProjectID: "1"}, "key"},
+		{"sample rate больше 1", Sentry{Host: "sentry.io", Key: "k", ProjectID: "1", SampleRate: 1.5}, "sampleRate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sentry.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package configo
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+func (t *ClientTLS) Validate() error {
+	if !t.Enabled {
+		return nil
+	}
+
+	var errs []error
+
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		errs = append(errs, fieldErrorf("certFile", "certFile и keyFile задаются только вместе"))
+	}
+
+	errs = append(errs,
+		checkFile("caFile", t.CAFile),
+		checkFile("certFile", t.CertFile),
+		checkFile("keyFile", t.KeyFile),
+	)
+
+	return errors.Join(errs...)
+}
+
+// TLSConfig собирает *tls.Config из настроек. Если TLS выключен, возвращает nil.
+func (t ClientTLS) TLSConfig() (*tls.Config, error) {
+	if !t.Enabled {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         t.ServerName,
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	}
+
+	if t.CAFile != "" {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("чтение CA сертификата: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("в файле %s нет PEM сертификатов", t.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if t.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("загрузка клиентского сертификата: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// checkFile проверяет, что указанный файл существует
+func checkFile(path, file string) error {
+	if file == "" {
+		return nil
+	}
+
+	if _, err := os.Stat(file); err != nil {
+		return fieldError(path, err)
+	}
+
+	return nil
+}